@@ -0,0 +1,110 @@
+package urn
+
+import (
+	"net/url"
+	"strings"
+)
+
+// A Builder constructs a URN from raw, unescaped parts.  Each part is
+// percent-encoded as required by its component, and the result is
+// validated by the parser when built.
+//
+//	id, err := urn.New("example").NSS("weather", "today").
+//		Query(url.Values{"lat": {"39.56"}}).
+//		Build()
+type Builder struct {
+	nid         string
+	nss         []string
+	resolve     Params
+	query       Params
+	fragment    string
+	hasFragment bool
+}
+
+// New returns a builder for a URN of the given namespace.
+func New(nid string) *Builder {
+	return &Builder{nid: nid}
+}
+
+// NSS appends raw parts to the NSS.  Parts are joined with a colon
+// after being encoded.
+func (b *Builder) NSS(parts ...string) *Builder {
+	b.nss = append(b.nss, parts...)
+
+	return b
+}
+
+// Resolve sets the r-component from key/value parameters, in the
+// "key=value&..." form ordered by key.
+func (b *Builder) Resolve(v url.Values) *Builder {
+	b.resolve = ParamsFromValues(v)
+
+	return b
+}
+
+// Query sets the q-component from key/value parameters, in the
+// "key=value&..." form ordered by key.
+func (b *Builder) Query(v url.Values) *Builder {
+	b.query = ParamsFromValues(v)
+
+	return b
+}
+
+// Fragment sets the raw f-component.
+func (b *Builder) Fragment(s string) *Builder {
+	b.fragment = s
+	b.hasFragment = true
+
+	return b
+}
+
+// Build encodes all parts and returns the resulting URN, or an error if
+// the parts do not make a valid URN.  The NID is not encoded, and must
+// be valid as is.
+func (b *Builder) Build() (*URN, error) {
+	if !isValidNID(b.nid) {
+		return nil, &Error{
+			Op:        "build",
+			Data:      b.nid,
+			Err:       ErrInvalidNID,
+			Component: ComponentNID,
+			Length:    len(b.nid),
+		}
+	}
+
+	var s strings.Builder
+
+	s.WriteString("urn:")
+	s.WriteString(b.nid)
+	s.WriteByte(':')
+
+	for i, part := range b.nss {
+		if i > 0 {
+			s.WriteByte(':')
+		}
+
+		e := EncodeStringNSS(part)
+		if i == 0 {
+			e = escapeLeading(e)
+		}
+
+		s.WriteString(e)
+	}
+
+	if r := b.resolve.encode(resolveParamEncoder); r != "" {
+		s.WriteString("?+")
+		s.WriteString(r)
+	}
+
+	if q := b.query.encode(queryParamEncoder); q != "" {
+		s.WriteString("?=")
+		s.WriteString(q)
+	}
+
+	if b.hasFragment {
+		s.WriteByte('#')
+		s.WriteString(EncodeStringComponent(b.fragment))
+	}
+
+	return Parse(s.String())
+}
@@ -0,0 +1,109 @@
+package urn_test
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/paulourio/go-urn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder(t *testing.T) {
+	t.Parallel()
+
+	for i, c := range builderCases {
+		msg := fmt.Sprintf("case %d", i+1)
+
+		id, err := c.Builder.Build()
+		if c.Err != nil {
+			assert.ErrorIs(t, err, c.Err, msg)
+
+			continue
+		}
+
+		if assert.NoError(t, err, msg) {
+			assert.Equal(t, c.Output, id.String(), msg)
+		}
+	}
+}
+
+func TestBuilderInvalidNID(t *testing.T) {
+	t.Parallel()
+
+	for i, nid := range []string{"ab:cd", "a", "", "-ab", "a b"} {
+		msg := fmt.Sprintf("case %d: %q", i+1, nid)
+
+		id, err := urn.New(nid).NSS("x").Build()
+		assert.Nil(t, id, msg)
+
+		var uerr *urn.Error
+		if assert.ErrorAs(t, err, &uerr, msg) {
+			assert.ErrorIs(t, err, urn.ErrInvalidNID, msg)
+			assert.Equal(t, "build", uerr.Op, msg)
+			assert.Equal(t, nid, uerr.Data, msg)
+			assert.Equal(t, urn.ComponentNID, uerr.Component, msg)
+		}
+	}
+}
+
+func TestBuilderEscaping(t *testing.T) {
+	t.Parallel()
+
+	q := url.Values{"a&b": {"1=2", "x?=y"}, "c": {"#"}}
+	r := url.Values{"k": {"?=v"}}
+
+	id, err := urn.New("example").NSS("a/b").Resolve(r).Query(q).Build()
+	if assert.NoError(t, err) {
+		// Delimiters inside keys and values must be escaped.
+		assert.Equal(t, "a%26b=1%3D2&a%26b=x?%3Dy&c=%23", id.Query)
+		assert.Equal(t, "k=%3F%3Dv", id.Resolve)
+	}
+}
+
+type builderTestCase struct {
+	Builder *urn.Builder
+	Output  string
+	Err     error
+}
+
+var builderCases = []*builderTestCase{
+	{
+		Builder: urn.New("ietf").NSS("rfc", "8141"),
+		Output:  "urn:ietf:rfc:8141",
+	},
+	{
+		Builder: urn.New("example").NSS("a b", "ç"),
+		Output:  "urn:example:a%20b:%C3%A7",
+	},
+	{
+		Builder: urn.New("example").NSS("/path/to?x"),
+		Output:  "urn:example:%2Fpath/to%3Fx",
+	},
+	{
+		Builder: urn.New("example").NSS("weather").Query(url.Values{
+			"op":  {"map"},
+			"lat": {"39.56"},
+		}),
+		Output: "urn:example:weather?=lat=39.56&op=map",
+	},
+	{
+		Builder: urn.New("example").NSS("foo").
+			Resolve(url.Values{"cc": {"uk"}}).
+			Query(url.Values{"q": {"a b"}}).
+			Fragment("sec/1?"),
+		Output: "urn:example:foo?+cc=uk?=q=a%20b#sec/1?",
+	},
+	{
+		Builder: urn.New("example").NSS("foo").Fragment(""),
+		Output:  "urn:example:foo#",
+	},
+	{
+		Builder: urn.New("example").NSS("foo").Query(url.Values{"a": {}}),
+		Output:  "urn:example:foo",
+	},
+	{Builder: urn.New("example"), Err: urn.ErrInvalidNSS},
+	{Builder: urn.New("example").NSS(""), Err: urn.ErrInvalidNSS},
+	{Builder: urn.New("e").NSS("foo"), Err: urn.ErrInvalidNID},
+	{Builder: urn.New("a b").NSS("foo"), Err: urn.ErrInvalidNID},
+}
@@ -0,0 +1,302 @@
+package urn
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults of the CachingResolver.
+const (
+	DefaultCacheSize        = 1024
+	DefaultCacheTTL         = 5 * time.Minute
+	DefaultCacheNegativeTTL = time.Minute
+)
+
+// A CacheOption configures a CachingResolver.
+type CacheOption func(*CachingResolver)
+
+// CacheSize sets the maximum number of cached responses.  Values below
+// one select DefaultCacheSize.
+func CacheSize(n int) CacheOption {
+	return func(c *CachingResolver) {
+		if n < 1 {
+			n = DefaultCacheSize
+		}
+
+		c.size = n
+	}
+}
+
+// CacheTTL sets how long responses are cached.
+func CacheTTL(d time.Duration) CacheOption {
+	return func(c *CachingResolver) {
+		c.ttl = d
+	}
+}
+
+// CacheNegativeTTL sets how long misses, errors wrapping ErrNotFound,
+// are cached.  Zero disables negative caching.
+func CacheNegativeTTL(d time.Duration) CacheOption {
+	return func(c *CachingResolver) {
+		c.negativeTTL = d
+	}
+}
+
+// CacheClock sets the clock of the cache, time.Now by default.
+func CacheClock(now func() time.Time) CacheOption {
+	return func(c *CachingResolver) {
+		c.now = now
+	}
+}
+
+// CachingResolver caches the responses of a resolver in a least
+// recently used cache, with a maximum size and a time to live.
+//
+// Requests are cached by service and by the assigned name of their URN
+// under the CaseNormalized method, so "URN:IETF:rfc:8141" and
+// "urn:ietf:rfc:8141" share an entry.  The hints and the normalized
+// q-component are part of the key too, as resolvers may use them.
+// Requests without a URN are not cached.
+//
+// Misses, errors wrapping ErrNotFound, are cached as well, for the
+// negative TTL.  Other errors are not cached.
+//
+// Concurrent requests for the same key are coalesced into a single
+// call of the resolver, whose result all of them receive.  The resolver
+// is called with the context of the first request, without its
+// cancellation and deadline, so that canceling a request does not fail
+// the others; waiting requests give up when their context is done.  If
+// the resolver panics, the panic propagates to the first request, and
+// the others fail with an error wrapping ErrResolverPanic.
+//
+// Responses are copied, but share the locations, resource bodies and
+// URNs with the cache, which callers must not modify.
+//
+// CachingResolver is safe for concurrent use by multiple goroutines.
+type CachingResolver struct {
+	Resolver Resolver
+
+	size        int
+	ttl         time.Duration
+	negativeTTL time.Duration
+	now         func() time.Time
+
+	mu      sync.Mutex
+	lru     *list.List // of *cacheEntry, most recently used first
+	entries map[string]*list.Element
+	calls   map[string]*cacheCall
+}
+
+type cacheEntry struct {
+	key     string
+	resp    *Response
+	err     error
+	expires time.Time
+}
+
+// cacheCall is a resolution in flight.
+type cacheCall struct {
+	done chan struct{}
+	resp *Response
+	err  error
+}
+
+// NewCachingResolver returns a resolver caching the responses of r.
+func NewCachingResolver(r Resolver, opts ...CacheOption) *CachingResolver {
+	c := &CachingResolver{
+		Resolver:    r,
+		size:        DefaultCacheSize,
+		ttl:         DefaultCacheTTL,
+		negativeTTL: DefaultCacheNegativeTTL,
+		now:         time.Now,
+		lru:         list.New(),
+		entries:     make(map[string]*list.Element),
+		calls:       make(map[string]*cacheCall),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Resolve implements Resolver.
+func (c *CachingResolver) Resolve(ctx context.Context, req *Request) (*Response, error) {
+	if req.URN == nil {
+		return c.Resolver.Resolve(ctx, req)
+	}
+
+	key := cacheKey(req)
+
+	c.mu.Lock()
+
+	if e, ok := c.get(key); ok {
+		c.mu.Unlock()
+
+		return e.resp.clone(), e.err
+	}
+
+	call, ok := c.calls[key]
+	if !ok {
+		call = &cacheCall{done: make(chan struct{})}
+		c.calls[key] = call
+	}
+
+	c.mu.Unlock()
+
+	if ok {
+		select {
+		case <-call.done:
+			return call.resp.clone(), call.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	c.resolve(context.WithoutCancel(ctx), key, req, call)
+
+	return call.resp.clone(), call.err
+}
+
+// resolve calls the resolver for the call in flight, then caches its
+// result and releases the waiting requests, even if the resolver panics.
+func (c *CachingResolver) resolve(ctx context.Context, key string, req *Request, call *cacheCall) {
+	returned := false
+
+	defer func() {
+		if !returned {
+			call.resp, call.err = nil, &Error{Op: "resolve", Data: req.URI, Err: ErrResolverPanic}
+		}
+
+		c.mu.Lock()
+		delete(c.calls, key)
+
+		if returned {
+			c.add(key, call.resp, call.err)
+		}
+
+		c.mu.Unlock()
+
+		close(call.done)
+	}()
+
+	call.resp, call.err = c.Resolver.Resolve(ctx, req)
+	returned = true
+}
+
+// Len returns the number of cached responses, including expired ones
+// not yet evicted.
+func (c *CachingResolver) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+// Purge removes all cached responses.
+func (c *CachingResolver) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lru.Init()
+	c.entries = make(map[string]*list.Element)
+}
+
+// get returns the cached entry of the key, if not expired.  The caller
+// must hold c.mu.
+func (c *CachingResolver) get(key string) (*cacheEntry, bool) {
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	e := el.Value.(*cacheEntry)
+
+	if !c.now().Before(e.expires) {
+		c.lru.Remove(el)
+		delete(c.entries, key)
+
+		return nil, false
+	}
+
+	c.lru.MoveToFront(el)
+
+	return e, true
+}
+
+// add caches the result of the key, evicting the least recently used
+// entries over the size.  The caller must hold c.mu.
+func (c *CachingResolver) add(key string, resp *Response, err error) {
+	ttl := c.ttl
+
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrNotFound):
+		ttl = c.negativeTTL
+	default:
+		return
+	}
+
+	if ttl <= 0 {
+		return
+	}
+
+	e := &cacheEntry{key: key, resp: resp.clone(), err: err, expires: c.now().Add(ttl)}
+
+	if el, ok := c.entries[key]; ok {
+		el.Value = e
+		c.lru.MoveToFront(el)
+
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(e)
+
+	for c.lru.Len() > c.size {
+		el := c.lru.Back()
+		c.lru.Remove(el)
+		delete(c.entries, el.Value.(*cacheEntry).key)
+	}
+}
+
+// cacheKey returns the key of a request with a URN.
+func cacheKey(req *Request) string {
+	var b strings.Builder
+
+	b.WriteString(string(req.Service))
+	b.WriteByte(' ')
+	b.WriteString(Key(req.URN, AssignedName, CaseNormalized))
+
+	if len(req.Hints) > 0 {
+		b.WriteString("?+")
+		b.WriteString(req.Hints.Encode())
+	}
+
+	if q := req.URN.Normalized().Query; q != "" {
+		b.WriteString("?=")
+		b.WriteString(q)
+	}
+
+	return b.String()
+}
+
+// clone returns a copy of the response with its own result slices, or
+// nil if r is nil.
+func (r *Response) clone() *Response {
+	if r == nil {
+		return nil
+	}
+
+	out := *r
+	out.Locations = append(out.Locations[:0:0], r.Locations...)
+	out.Resources = append(out.Resources[:0:0], r.Resources...)
+	out.Characteristics = append(out.Characteristics[:0:0], r.Characteristics...)
+	out.URNs = append(out.URNs[:0:0], r.URNs...)
+
+	return &out
+}
@@ -0,0 +1,420 @@
+package urn_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/paulourio/go-urn"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingResolver resolves the "miss" NSS to ErrNotFound, "fail" to an
+// error, panics for "panic", and resolves other URNs to a location
+// derived from the NSS, counting the calls.  If release is set, calls
+// block until it is closed.
+type countingResolver struct {
+	calls   atomic.Int64
+	release chan struct{}
+}
+
+func (r *countingResolver) Resolve(ctx context.Context, req *urn.Request) (*urn.Response, error) {
+	r.calls.Add(1)
+
+	if r.release != nil {
+		select {
+		case <-r.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	switch req.URN.NSS {
+	case "miss":
+		return nil, fmt.Errorf("test: %w", urn.ErrNotFound)
+	case "fail":
+		return nil, errors.New("test: backend down")
+	case "panic":
+		panic("test: backend panic")
+	}
+
+	u, err := url.Parse("https://example.com/" + req.URN.NSS + "?" + req.Hints.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	return &urn.Response{Service: req.Service, Locations: []*url.URL{u}}, nil
+}
+
+// testClock is a manual clock.
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+func resolveString(t *testing.T, r urn.Resolver, service urn.Service, s string) (string, error) {
+	t.Helper()
+
+	u, err := urn.Parse(s)
+	if !assert.NoError(t, err, s) {
+		return "", err
+	}
+
+	resp, err := r.Resolve(context.Background(), urn.NewRequest(service, u))
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Location().String(), nil
+}
+
+func TestCachingResolver(t *testing.T) {
+	t.Parallel()
+
+	backend := &countingResolver{}
+	r := urn.NewCachingResolver(backend)
+
+	cases := []struct {
+		Input    string
+		Service  urn.Service
+		Location string
+		Err      error
+		Calls    int64
+	}{
+		{Input: "urn:example:a", Service: urn.N2L, Location: "https://example.com/a?", Calls: 1},
+		{Input: "URN:EXAMPLE:a", Service: urn.N2L, Location: "https://example.com/a?", Calls: 1},
+		{Input: "urn:Example:a#frag", Service: urn.N2L, Location: "https://example.com/a?", Calls: 1},
+		{Input: "urn:example:A", Service: urn.N2L, Location: "https://example.com/A?", Calls: 2},
+		{Input: "urn:example:a", Service: urn.N2Ls, Location: "https://example.com/a?", Calls: 3},
+		{Input: "urn:example:a?+x=1", Service: urn.N2L, Location: "https://example.com/a?x=1", Calls: 4},
+		{Input: "urn:example:a?+x=1", Service: urn.N2L, Location: "https://example.com/a?x=1", Calls: 4},
+		{Input: "urn:example:a?=y=1", Service: urn.N2L, Location: "https://example.com/a?", Calls: 5},
+		{Input: "urn:example:miss", Service: urn.N2L, Err: urn.ErrNotFound, Calls: 6},
+		{Input: "urn:EXAMPLE:miss", Service: urn.N2L, Err: urn.ErrNotFound, Calls: 6},
+		{Input: "urn:example:fail", Service: urn.N2L, Err: errors.New("test: backend down"), Calls: 7},
+		{Input: "urn:example:fail", Service: urn.N2L, Err: errors.New("test: backend down"), Calls: 8},
+	}
+
+	for i, c := range cases {
+		msg := fmt.Sprintf("case %d: %q", i+1, c.Input)
+
+		loc, err := resolveString(t, r, c.Service, c.Input)
+
+		switch {
+		case errors.Is(c.Err, urn.ErrNotFound):
+			assert.ErrorIs(t, err, c.Err, msg)
+		case c.Err != nil:
+			assert.EqualError(t, err, c.Err.Error(), msg)
+		default:
+			assert.NoError(t, err, msg)
+			assert.Equal(t, c.Location, loc, msg)
+		}
+
+		assert.Equal(t, c.Calls, backend.calls.Load(), msg)
+	}
+
+	assert.Equal(t, 6, r.Len())
+
+	r.Purge()
+	assert.Equal(t, 0, r.Len())
+
+	_, _ = resolveString(t, r, urn.N2L, "urn:example:a")
+	assert.Equal(t, int64(9), backend.calls.Load())
+}
+
+func TestCachingResolverTTL(t *testing.T) {
+	t.Parallel()
+
+	clock := &testClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	backend := &countingResolver{}
+	c := urn.NewCachingResolver(backend,
+		urn.CacheTTL(time.Minute),
+		urn.CacheNegativeTTL(10*time.Second),
+		urn.CacheClock(clock.Now))
+
+	_, _ = resolveString(t, c, urn.N2L, "urn:example:a")
+	_, _ = resolveString(t, c, urn.N2L, "urn:example:miss")
+	assert.Equal(t, int64(2), backend.calls.Load())
+
+	clock.Advance(10 * time.Second)
+
+	_, _ = resolveString(t, c, urn.N2L, "urn:example:a")
+	_, _ = resolveString(t, c, urn.N2L, "urn:example:miss")
+	assert.Equal(t, int64(3), backend.calls.Load())
+
+	clock.Advance(50 * time.Second)
+
+	_, _ = resolveString(t, c, urn.N2L, "urn:example:a")
+	assert.Equal(t, int64(4), backend.calls.Load())
+
+	// Without negative caching, misses are always resolved.
+	c = urn.NewCachingResolver(backend, urn.CacheNegativeTTL(0))
+
+	_, _ = resolveString(t, c, urn.N2L, "urn:example:miss")
+	_, _ = resolveString(t, c, urn.N2L, "urn:example:miss")
+	assert.Equal(t, int64(6), backend.calls.Load())
+}
+
+func TestCachingResolverLRU(t *testing.T) {
+	t.Parallel()
+
+	backend := &countingResolver{}
+	c := urn.NewCachingResolver(backend, urn.CacheSize(2))
+
+	_, _ = resolveString(t, c, urn.N2L, "urn:example:a")
+	_, _ = resolveString(t, c, urn.N2L, "urn:example:b")
+	_, _ = resolveString(t, c, urn.N2L, "urn:example:a") // b is now the oldest
+	_, _ = resolveString(t, c, urn.N2L, "urn:example:c") // evicts b
+	assert.Equal(t, 2, c.Len())
+	assert.Equal(t, int64(3), backend.calls.Load())
+
+	_, _ = resolveString(t, c, urn.N2L, "urn:example:a")
+	_, _ = resolveString(t, c, urn.N2L, "urn:example:c")
+	assert.Equal(t, int64(3), backend.calls.Load())
+
+	_, _ = resolveString(t, c, urn.N2L, "urn:example:b")
+	assert.Equal(t, int64(4), backend.calls.Load())
+}
+
+func TestCachingResolverCopies(t *testing.T) {
+	t.Parallel()
+
+	c := urn.NewCachingResolver(&countingResolver{})
+
+	u, _ := urn.Parse("urn:example:a")
+
+	resp, err := c.Resolve(context.Background(), urn.NewRequest(urn.N2L, u))
+	if assert.NoError(t, err) {
+		resp.Locations = nil
+	}
+
+	resp, err = c.Resolve(context.Background(), urn.NewRequest(urn.N2L, u))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "https://example.com/a?", resp.Location().String())
+	}
+}
+
+func TestCachingResolverNoURN(t *testing.T) {
+	t.Parallel()
+
+	backend := &locationsResolver{urls: []string{"https://example.com/"}}
+	c := urn.NewCachingResolver(backend)
+
+	for i := 0; i < 2; i++ {
+		_, err := c.Resolve(context.Background(), &urn.Request{Service: urn.I2Ls, URI: "https://example.com/"})
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestCachingResolverConcurrent(t *testing.T) {
+	t.Parallel()
+
+	const goroutines = 100
+
+	backend := &countingResolver{release: make(chan struct{})}
+	c := urn.NewCachingResolver(backend, urn.CacheSize(4))
+
+	ids := []string{"urn:example:a", "URN:EXAMPLE:a", "urn:example:b", "urn:example:miss"}
+
+	var (
+		wg      sync.WaitGroup
+		started sync.WaitGroup
+		errs    = make([]error, goroutines)
+		locs    = make([]string, goroutines)
+	)
+
+	wg.Add(goroutines)
+	started.Add(goroutines)
+
+	for i := 0; i < goroutines; i++ {
+		go func(i int) {
+			defer wg.Done()
+
+			started.Done()
+			locs[i], errs[i] = resolveString(t, c, urn.N2L, ids[i%len(ids)])
+		}(i)
+	}
+
+	started.Wait()
+
+	// Let the lookups start and coalesce before they complete.
+	assert.Eventually(t, func() bool { return backend.calls.Load() == 3 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	close(backend.release)
+	wg.Wait()
+
+	for i := 0; i < goroutines; i++ {
+		msg := fmt.Sprintf("goroutine %d: %q", i, ids[i%len(ids)])
+
+		switch i % len(ids) {
+		case 0, 1:
+			assert.NoError(t, errs[i], msg)
+			assert.Equal(t, "https://example.com/a?", locs[i], msg)
+		case 2:
+			assert.NoError(t, errs[i], msg)
+			assert.Equal(t, "https://example.com/b?", locs[i], msg)
+		case 3:
+			assert.ErrorIs(t, errs[i], urn.ErrNotFound, msg)
+		}
+	}
+
+	assert.Equal(t, int64(3), backend.calls.Load())
+
+	// Concurrent readers and writers of a small cache.
+	backend = &countingResolver{}
+	c = urn.NewCachingResolver(backend, urn.CacheSize(8))
+
+	wg.Add(goroutines)
+
+	for i := 0; i < goroutines; i++ {
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < 50; j++ {
+				id := fmt.Sprintf("urn:example:n%d", (i+j)%16)
+
+				loc, err := resolveString(t, c, urn.N2L, id)
+				if assert.NoError(t, err, id) {
+					assert.Equal(t, fmt.Sprintf("https://example.com/n%d?", (i+j)%16), loc, id)
+				}
+
+				if j%10 == 0 {
+					_ = c.Len()
+				}
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	assert.LessOrEqual(t, c.Len(), 8)
+}
+
+func TestCachingResolverCanceled(t *testing.T) {
+	t.Parallel()
+
+	backend := &countingResolver{release: make(chan struct{})}
+	c := urn.NewCachingResolver(backend)
+
+	u, _ := urn.Parse("urn:example:a")
+
+	// The first caller starts the lookup, then gives up.
+	first, cancelFirst := context.WithCancel(context.Background())
+	firstDone := make(chan error, 1)
+
+	go func() {
+		_, err := c.Resolve(first, urn.NewRequest(urn.N2L, u))
+		firstDone <- err
+	}()
+
+	assert.Eventually(t, func() bool { return backend.calls.Load() == 1 }, time.Second, time.Millisecond)
+
+	// A waiting caller receives the result of the lookup in flight.
+	type result struct {
+		loc string
+		err error
+	}
+
+	waiterDone := make(chan result, 1)
+
+	go func() {
+		loc, err := resolveString(t, c, urn.N2L, "urn:example:a")
+		waiterDone <- result{loc, err}
+	}()
+
+	cancelFirst()
+
+	// A waiting caller gives up with its context, without affecting the
+	// lookup in flight.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.Resolve(ctx, urn.NewRequest(urn.N2L, u))
+	assert.ErrorIs(t, err, context.Canceled)
+
+	close(backend.release)
+
+	assert.NoError(t, <-firstDone)
+
+	r := <-waiterDone
+	assert.NoError(t, r.err)
+	assert.Equal(t, "https://example.com/a?", r.loc)
+
+	loc, err := resolveString(t, c, urn.N2L, "urn:example:a")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/a?", loc)
+	assert.Equal(t, int64(1), backend.calls.Load())
+}
+
+func TestCachingResolverPanic(t *testing.T) {
+	t.Parallel()
+
+	backend := &countingResolver{release: make(chan struct{})}
+	c := urn.NewCachingResolver(backend)
+
+	u, _ := urn.Parse("urn:example:panic")
+
+	firstDone := make(chan interface{}, 1)
+
+	go func() {
+		defer func() { firstDone <- recover() }()
+
+		_, _ = c.Resolve(context.Background(), urn.NewRequest(urn.N2L, u))
+	}()
+
+	assert.Eventually(t, func() bool { return backend.calls.Load() == 1 }, time.Second, time.Millisecond)
+
+	waiterDone := make(chan error, 1)
+
+	go func() {
+		// A waiter arriving after the panic calls the resolver again.
+		defer func() {
+			if v := recover(); v != nil {
+				waiterDone <- nil
+			}
+		}()
+
+		_, err := c.Resolve(context.Background(), urn.NewRequest(urn.N2L, u))
+		waiterDone <- err
+	}()
+
+	// Let the waiter join the lookup in flight.
+	time.Sleep(10 * time.Millisecond)
+	close(backend.release)
+
+	// The panic propagates to the first caller, and fails the waiter.
+	assert.Equal(t, "test: backend panic", <-firstDone)
+
+	if err := <-waiterDone; err != nil {
+		var uerr *urn.Error
+		if assert.ErrorAs(t, err, &uerr) {
+			assert.ErrorIs(t, err, urn.ErrResolverPanic)
+			assert.Equal(t, `resolve "urn:example:panic": resolver panicked`, uerr.Error())
+		}
+	}
+
+	// Panics are not cached.
+	assert.Equal(t, 0, c.Len())
+	assert.Panics(t, func() { _, _ = c.Resolve(context.Background(), urn.NewRequest(urn.N2L, u)) })
+}
@@ -0,0 +1,438 @@
+// Command urn parses, validates, normalizes, compares and encodes URNs.
+//
+// Usage:
+//
+//	urn parse [-format=text|json] [urn ...]
+//	urn validate [-namespace=true|false] [urn ...]
+//	urn normalize [-method=case|encoding|namespace] [urn ...]
+//	urn equal [-part=assigned|all] [-method=...] [a b]
+//	urn encode [-component=nss|component] [text ...]
+//	urn decode [text ...]
+//
+// Without arguments, inputs are read from newline-delimited standard
+// input.  For equal, each input line holds the two URNs separated by
+// white space.
+//
+// The validate command warns about NIDs that are not registered with
+// IANA, but does not fail for them.  It only warns if the embedded
+// snapshot of the IANA registry is complete, as an excerpt would report
+// registered NIDs as unknown.
+//
+// The exit status is 0 on success, 1 when any input is invalid (or not
+// equal, for equal), and 2 on usage errors.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/paulourio/go-urn"
+)
+
+const (
+	exitOK    = 0
+	exitFail  = 1
+	exitUsage = 2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+type command struct {
+	name   string
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	flags  *flag.FlagSet
+}
+
+var usage = `usage: urn <command> [flags] [input ...]
+
+commands:
+  parse      print the components of URNs
+  validate   check URNs against the syntax and namespace rules
+  normalize  print normalized URNs
+  equal      compare two URNs
+  encode     percent-encode text for use in a URN
+  decode     percent-decode text
+`
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+
+		return exitUsage
+	}
+
+	c := &command{
+		name:   args[0],
+		stdin:  stdin,
+		stdout: stdout,
+		stderr: stderr,
+		flags:  flag.NewFlagSet(args[0], flag.ContinueOnError),
+	}
+	c.flags.SetOutput(stderr)
+
+	switch c.name {
+	case "parse":
+		return c.parse(args[1:])
+	case "validate":
+		return c.validate(args[1:])
+	case "normalize":
+		return c.normalize(args[1:])
+	case "equal":
+		return c.equal(args[1:])
+	case "encode":
+		return c.encode(args[1:])
+	case "decode":
+		return c.decode(args[1:])
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+
+		return exitOK
+	}
+
+	fmt.Fprintf(stderr, "urn: unknown command %q\n%s", c.name, usage)
+
+	return exitUsage
+}
+
+type parseOutput struct {
+	Scheme   string  `json:"scheme"`
+	NID      string  `json:"nid"`
+	NSS      string  `json:"nss"`
+	Resolve  string  `json:"resolve,omitempty"`
+	Query    string  `json:"query,omitempty"`
+	Fragment *string `json:"fragment,omitempty"`
+}
+
+func (c *command) parse(args []string) int {
+	format := c.flags.String("format", "text", "output `format`: text or json")
+
+	inputs, ok := c.parseFlags(args)
+	if !ok {
+		return exitUsage
+	}
+
+	if *format != "text" && *format != "json" {
+		return c.usageError("invalid format %q", *format)
+	}
+
+	enc := json.NewEncoder(c.stdout)
+	enc.SetEscapeHTML(false)
+
+	return c.each(inputs, func(s string) bool {
+		u, err := urn.Parse(s)
+		if err != nil {
+			c.report(err)
+
+			return false
+		}
+
+		if *format == "json" {
+			out := &parseOutput{
+				Scheme:  u.Scheme,
+				NID:     u.NID,
+				NSS:     u.NSS,
+				Resolve: u.Resolve,
+				Query:   u.Query,
+			}
+
+			if u.Fragment != "" || u.ForceFragment {
+				out.Fragment = &u.Fragment
+			}
+
+			_ = enc.Encode(out)
+
+			return true
+		}
+
+		fmt.Fprintf(c.stdout, "scheme:   %s\n", u.Scheme)
+		fmt.Fprintf(c.stdout, "nid:      %s\n", u.NID)
+		fmt.Fprintf(c.stdout, "nss:      %s\n", u.NSS)
+		fmt.Fprintf(c.stdout, "resolve:  %s\n", u.Resolve)
+		fmt.Fprintf(c.stdout, "query:    %s\n", u.Query)
+		fmt.Fprintf(c.stdout, "fragment: %s\n\n", u.Fragment)
+
+		return true
+	})
+}
+
+func (c *command) validate(args []string) int {
+	namespace := c.flags.Bool("namespace", true, "check namespace-specific rules")
+
+	inputs, ok := c.parseFlags(args)
+	if !ok {
+		return exitUsage
+	}
+
+	return c.each(inputs, func(s string) bool {
+		var (
+			u   *urn.URN
+			err error
+		)
+
+		if *namespace {
+			u, err = urn.DefaultRegistry.Parse(s)
+		} else {
+			u, err = urn.Parse(s)
+		}
+
+		if err != nil {
+			c.report(err)
+
+			return false
+		}
+
+		if _, ok := urn.LookupNamespace(u.NID); !ok && ianaComplete {
+			fmt.Fprintf(c.stderr, "urn: warning: %s: NID %q is not registered with IANA\n", s, u.NID)
+		}
+
+		fmt.Fprintf(c.stdout, "%s: ok\n", s)
+
+		return true
+	})
+}
+
+// ianaComplete reports whether the IANA registry snapshot is complete,
+// so that unknown NIDs are not registered.
+var _, ianaComplete = urn.IANASnapshot()
+
+func (c *command) normalize(args []string) int {
+	name := c.flags.String("method", "case", "normalization `method`: case, encoding or namespace")
+
+	inputs, ok := c.parseFlags(args)
+	if !ok {
+		return exitUsage
+	}
+
+	method, ok := methods[*name]
+	if !ok || method == urn.Simple {
+		return c.usageError("invalid method %q", *name)
+	}
+
+	return c.each(inputs, func(s string) bool {
+		u, err := urn.Parse(s)
+		if err != nil {
+			c.report(err)
+
+			return false
+		}
+
+		fmt.Fprintln(c.stdout, u.NormalizedBy(method).String())
+
+		return true
+	})
+}
+
+func (c *command) equal(args []string) int {
+	partName := c.flags.String("part", "assigned", "compared `part`: assigned or all")
+	methodName := c.flags.String("method", "case",
+		"comparison `method`: simple, case, encoding or namespace")
+
+	inputs, ok := c.parseFlags(args)
+	if !ok {
+		return exitUsage
+	}
+
+	part, ok := parts[*partName]
+	if !ok {
+		return c.usageError("invalid part %q", *partName)
+	}
+
+	method, ok := methods[*methodName]
+	if !ok {
+		return c.usageError("invalid method %q", *methodName)
+	}
+
+	compare := func(a, b string) bool {
+		ua, err := urn.Parse(a)
+		if err != nil {
+			c.report(err)
+
+			return false
+		}
+
+		ub, err := urn.Parse(b)
+		if err != nil {
+			c.report(err)
+
+			return false
+		}
+
+		eq := urn.Equal(ua, ub, part, method)
+		fmt.Fprintln(c.stdout, eq)
+
+		return eq
+	}
+
+	if len(inputs) > 0 {
+		if len(inputs) != 2 {
+			return c.usageError("equal requires two URNs")
+		}
+
+		if compare(inputs[0], inputs[1]) {
+			return exitOK
+		}
+
+		return exitFail
+	}
+
+	return c.each(nil, func(line string) bool {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			fmt.Fprintf(c.stderr, "urn: expected two URNs in %q\n", line)
+
+			return false
+		}
+
+		return compare(fields[0], fields[1])
+	})
+}
+
+func (c *command) encode(args []string) int {
+	component := c.flags.String("component", "nss", "target `component`: nss or component")
+
+	inputs, ok := c.parseFlags(args)
+	if !ok {
+		return exitUsage
+	}
+
+	var encode func(string) string
+
+	switch *component {
+	case "nss":
+		encode = urn.EncodeStringNSS
+	case "component":
+		encode = urn.EncodeStringComponent
+	default:
+		return c.usageError("invalid component %q", *component)
+	}
+
+	return c.each(inputs, func(s string) bool {
+		fmt.Fprintln(c.stdout, encode(s))
+
+		return true
+	})
+}
+
+func (c *command) decode(args []string) int {
+	inputs, ok := c.parseFlags(args)
+	if !ok {
+		return exitUsage
+	}
+
+	return c.each(inputs, func(s string) bool {
+		d, err := urn.DecodeString(s)
+		if err != nil {
+			c.report(err)
+
+			return false
+		}
+
+		fmt.Fprintf(c.stdout, "%s\n", d)
+
+		return true
+	})
+}
+
+var methods = map[string]urn.ComparisonMethod{
+	"simple":    urn.Simple,
+	"case":      urn.CaseNormalized,
+	"encoding":  urn.EncodingNormalized,
+	"namespace": urn.NamespaceNormalized,
+}
+
+var parts = map[string]urn.ComparisonPart{
+	"assigned": urn.AssignedName,
+	"all":      urn.AllParts,
+}
+
+// parseFlags parses flags that may be interleaved with positional
+// arguments, and returns the positional arguments.
+func (c *command) parseFlags(args []string) ([]string, bool) {
+	var inputs []string
+
+	for {
+		if err := c.flags.Parse(args); err != nil {
+			return nil, false
+		}
+
+		args = c.flags.Args()
+		if len(args) == 0 {
+			return inputs, true
+		}
+
+		if args[0] == "--" {
+			return append(inputs, args[1:]...), true
+		}
+
+		inputs = append(inputs, args[0])
+		args = args[1:]
+	}
+}
+
+// each calls fn for each input, or for each line of the standard input
+// if there are no inputs.  It returns exitFail if fn fails for any
+// input.
+func (c *command) each(inputs []string, fn func(string) bool) int {
+	status := exitOK
+
+	if len(inputs) > 0 {
+		for _, s := range inputs {
+			if !fn(s) {
+				status = exitFail
+			}
+		}
+
+		return status
+	}
+
+	scanner := bufio.NewScanner(c.stdin)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+
+		if !fn(line) {
+			status = exitFail
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(c.stderr, "urn: reading input: %v\n", err)
+
+		return exitFail
+	}
+
+	return status
+}
+
+func (c *command) report(err error) {
+	var uerr *urn.Error
+
+	if errors.As(err, &uerr) {
+		fmt.Fprintf(c.stderr, "%s\n", uerr.Pretty())
+
+		return
+	}
+
+	fmt.Fprintf(c.stderr, "urn: %v\n", err)
+}
+
+func (c *command) usageError(format string, args ...interface{}) int {
+	fmt.Fprintf(c.stderr, "urn %s: %s\n", c.name, fmt.Sprintf(format, args...))
+	c.flags.Usage()
+
+	return exitUsage
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	for _, c := range runCases {
+		c := c
+
+		t.Run(strings.Join(c.Args, " "), func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+
+			code := run(c.Args, strings.NewReader(c.Stdin), &stdout, &stderr)
+
+			assert.Equal(t, c.Code, code, "stderr: %s", stderr.String())
+			assert.Equal(t, c.Stdout, stdout.String())
+
+			if c.Stderr != "" {
+				assert.Contains(t, stderr.String(), c.Stderr)
+			}
+		})
+	}
+}
+
+func TestValidateIANAWarning(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"validate", "urn:unknown:x", "urn:ietf:rfc:8141"}, nil, &stdout, &stderr)
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "urn:unknown:x: ok\nurn:ietf:rfc:8141: ok\n", stdout.String())
+
+	assert.Equal(t, "urn: warning: urn:unknown:x: NID \"unknown\" is not registered with IANA\n", stderr.String())
+}
+
+type runTestCase struct {
+	Args   []string
+	Stdin  string
+	Code   int
+	Stdout string
+	Stderr string
+}
+
+var runCases = []*runTestCase{
+	{Args: nil, Code: exitUsage, Stderr: "usage: urn"},
+	{Args: []string{"bogus"}, Code: exitUsage, Stderr: `unknown command "bogus"`},
+	{
+		Args: []string{"parse", "urn:ietf:rfc:8141?+r?=q#f"},
+		Code: exitOK,
+		Stdout: "scheme:   urn\nnid:      ietf\nnss:      rfc:8141\n" +
+			"resolve:  r\nquery:    q\nfragment: f\n\n",
+	},
+	{
+		Args:  []string{"parse", "-format=json"},
+		Stdin: "urn:ietf:rfc:8141\n\nurn:a:b\nurn:aa:b&c#\n",
+		Code:  exitFail,
+		Stdout: `{"scheme":"urn","nid":"ietf","nss":"rfc:8141"}` + "\n" +
+			`{"scheme":"urn","nid":"aa","nss":"b&c","fragment":""}` + "\n",
+		Stderr: "urn:a:b\n      ^",
+	},
+	{Args: []string{"parse", "-format=xml"}, Code: exitUsage, Stderr: "invalid format"},
+	{
+		Args:   []string{"validate", "urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6"},
+		Code:   exitOK,
+		Stdout: "urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6: ok\n",
+	},
+	{
+		Args:   []string{"validate", "urn:unknown:x"},
+		Code:   exitOK,
+		Stdout: "urn:unknown:x: ok\n",
+	},
+	{
+		Args:   []string{"validate", "urn:uuid:banana"},
+		Code:   exitFail,
+		Stderr: "uuid: invalid length",
+	},
+	{
+		Args:   []string{"validate", "urn:uuid:banana", "-namespace=false"},
+		Code:   exitOK,
+		Stdout: "urn:uuid:banana: ok\n",
+	},
+	{
+		Args:   []string{"normalize"},
+		Stdin:  "URN:Example:a%2fb%41\r\n",
+		Code:   exitOK,
+		Stdout: "urn:example:a%2Fb%41\n",
+	},
+	{
+		Args:   []string{"normalize", "--method=encoding", "URN:Example:a%2fb%41"},
+		Code:   exitOK,
+		Stdout: "urn:example:a/bA\n",
+	},
+	{
+		Args:   []string{"normalize", "-method=namespace", "urn:UUID:F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6"},
+		Code:   exitOK,
+		Stdout: "urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6\n",
+	},
+	{Args: []string{"normalize", "-method=simple"}, Code: exitUsage, Stderr: "invalid method"},
+	{
+		Args:   []string{"equal", "URN:IETF:rfc:8141", "urn:ietf:rfc:8141?=x"},
+		Code:   exitOK,
+		Stdout: "true\n",
+	},
+	{
+		Args:   []string{"equal", "URN:IETF:rfc:8141", "urn:ietf:rfc:8141?=x", "--part=all"},
+		Code:   exitFail,
+		Stdout: "false\n",
+	},
+	{
+		Args:   []string{"equal", "-method=simple"},
+		Stdin:  "urn:ab:c urn:ab:c\nurn:ab:c URN:ab:c\nurn:ab:c\n",
+		Code:   exitFail,
+		Stdout: "true\nfalse\n",
+		Stderr: "expected two URNs",
+	},
+	{Args: []string{"equal", "urn:ab:c"}, Code: exitUsage, Stderr: "requires two URNs"},
+	{Args: []string{"equal", "-part=x"}, Code: exitUsage, Stderr: "invalid part"},
+	{
+		Args:   []string{"encode", "a b/c?d"},
+		Code:   exitOK,
+		Stdout: "a%20b/c%3Fd\n",
+	},
+	{
+		Args:   []string{"encode", "-component=component", "--", "a b/c?d"},
+		Code:   exitOK,
+		Stdout: "a%20b/c?d\n",
+	},
+	{Args: []string{"encode", "-component=x"}, Code: exitUsage, Stderr: "invalid component"},
+	{
+		Args:   []string{"decode"},
+		Stdin:  "a%20b%2Fc\n",
+		Code:   exitOK,
+		Stdout: "a b/c\n",
+	},
+	{
+		Args:   []string{"decode", "100%"},
+		Code:   exitFail,
+		Stderr: "decode \"100%\": invalid percent-encoding: invalid escape \"%\"\n  100%\n     ^\n",
+	},
+	{Args: []string{"decode", "a%2"}, Code: exitFail, Stderr: `invalid escape "%2"`},
+	{Args: []string{"decode", "%zz"}, Code: exitFail, Stderr: `invalid escape "%zz"`},
+	{
+		Args:   []string{"decode"},
+		Stdin:  "a%20b\nc%2\nd\n",
+		Code:   exitFail,
+		Stdout: "a b\nd\n",
+		Stderr: `invalid escape "%2"`,
+	},
+}
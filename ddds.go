@@ -0,0 +1,452 @@
+package urn
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// A NAPTR is a DNS Naming Authority Pointer record, from
+// [RFC 3403](urn:ietf:rfc:3403).
+type NAPTR struct {
+	Order       uint16
+	Preference  uint16
+	Flags       string
+	Services    string
+	Regexp      string
+	Replacement string
+}
+
+// DNS is the DNS access needed by the DDDSResolver.  The standard
+// library cannot look up NAPTR records, so implementations usually wrap
+// a DNS client library; Zone is an in-memory implementation.
+type DNS interface {
+	// LookupNAPTR returns the NAPTR records of a fully qualified name.
+	// It returns no records and no error if the name has none.
+	LookupNAPTR(ctx context.Context, name string) ([]NAPTR, error)
+
+	// LookupSRV returns the SRV records of a fully qualified name, such
+	// as "_http._tcp.example.com.".
+	LookupSRV(ctx context.Context, name string) ([]*net.SRV, error)
+}
+
+// Zone is an in-memory DNS zone, for tests and static configurations.
+// Names are case-insensitive, and the trailing dot is optional.  It is
+// safe for concurrent use by multiple goroutines.
+type Zone struct {
+	mu    sync.RWMutex
+	naptr map[string][]NAPTR
+	srv   map[string][]*net.SRV
+}
+
+// NewZone returns an empty zone.
+func NewZone() *Zone {
+	return &Zone{naptr: make(map[string][]NAPTR), srv: make(map[string][]*net.SRV)}
+}
+
+// AddNAPTR adds NAPTR records to the name.
+func (z *Zone) AddNAPTR(name string, records ...NAPTR) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	name = canonicalName(name)
+	z.naptr[name] = append(z.naptr[name], records...)
+}
+
+// AddSRV adds SRV records to the name.
+func (z *Zone) AddSRV(name string, records ...*net.SRV) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	name = canonicalName(name)
+	z.srv[name] = append(z.srv[name], records...)
+}
+
+// LookupNAPTR implements DNS.
+func (z *Zone) LookupNAPTR(_ context.Context, name string) ([]NAPTR, error) {
+	z.mu.RLock()
+	defer z.mu.RUnlock()
+
+	return append([]NAPTR(nil), z.naptr[canonicalName(name)]...), nil
+}
+
+// LookupSRV implements DNS.
+func (z *Zone) LookupSRV(_ context.Context, name string) ([]*net.SRV, error) {
+	z.mu.RLock()
+	defer z.mu.RUnlock()
+
+	return append([]*net.SRV(nil), z.srv[canonicalName(name)]...), nil
+}
+
+func canonicalName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, ".")) + "."
+}
+
+// URNSuffix is the domain of the first well known rule of URNs, from
+// [RFC 3405](urn:ietf:rfc:3405).
+const URNSuffix = "urn.arpa."
+
+// maxDDDSSteps bounds the number of non-terminal rewrites.
+const maxDDDSSteps = 16
+
+// DDDSResolver resolves URNs with the Dynamic Delegation Discovery
+// System, following [RFC 3404](urn:ietf:rfc:3404):
+//
+//  1. The first well known rule turns the NID into the key
+//     "<nid>.urn.arpa.".
+//  2. The NAPTR records of the key are considered by order and
+//     preference.  Records of a lower order take precedence, and records
+//     of higher orders are only used if no record of the lower one
+//     applies.
+//  3. The regular expression of a record is applied to the normalized
+//     assigned name of the URN, or else the replacement is used.
+//  4. Records without flags are non-terminal: the result is the key of
+//     the next lookup.  Terminal records end the resolution: with flag
+//     "U" the result is a URL; with "S" it is a name to look up SRV
+//     records, and with "A" a host name, both of which serve the THTTP
+//     resolution protocol of [RFC 2169](urn:ietf:rfc:2169).  Records with
+//     flag "P" are protocol-specific and skipped, as are unknown flags.
+//
+// It implements the N2L, N2Ls, I2L and I2Ls services.  Errors are of
+// type *Error, with the normalized assigned name as Data, and wrap
+// ErrRewriteLoop if the rewrites do not end.
+type DDDSResolver struct {
+	DNS DNS
+
+	// Suffix is the domain of the first well known rule.  If empty,
+	// URNSuffix is used.
+	Suffix string
+}
+
+// NewDDDSResolver returns a resolver using the DNS.
+func NewDDDSResolver(dns DNS) *DDDSResolver {
+	return &DDDSResolver{DNS: dns}
+}
+
+// Resolve implements Resolver.
+func (r *DDDSResolver) Resolve(ctx context.Context, req *Request) (*Response, error) {
+	switch req.Service {
+	case N2L, N2Ls, I2L, I2Ls:
+	default:
+		return nil, &Error{
+			Op:   "ddds",
+			Data: req.URI,
+			Err:  ErrUnsupportedService,
+			Msg:  fmt.Sprintf("service %s", req.Service),
+		}
+	}
+
+	if req.URN == nil {
+		return nil, &Error{Op: "ddds", Data: req.URI, Err: ErrUnsupportedService, Msg: "not a URN"}
+	}
+
+	locs, err := r.locations(ctx, req.URN.Normalized().AssignedName(), req.URN.NID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &Response{Service: req.Service, Locations: locs}
+
+	resp.truncate(req.Service)
+
+	return resp, nil
+}
+
+func (r *DDDSResolver) locations(ctx context.Context, aus, nid string) ([]*url.URL, error) {
+	suffix := r.Suffix
+	if suffix == "" {
+		suffix = URNSuffix
+	}
+
+	key := canonicalName(nid + "." + suffix)
+	seen := map[string]bool{}
+
+	for step := 0; step < maxDDDSSteps; step++ {
+		if seen[key] {
+			return nil, &Error{Op: "ddds", Data: aus, Err: ErrRewriteLoop, Msg: "key " + key}
+		}
+
+		seen[key] = true
+
+		records, err := r.DNS.LookupNAPTR(ctx, key)
+		if err != nil {
+			return nil, &Error{Op: "ddds", Data: aus, Err: err, Msg: "NAPTR " + key}
+		}
+
+		locs, next, err := r.apply(ctx, records, aus)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(locs) > 0 {
+			return locs, nil
+		}
+
+		if next == "" {
+			return nil, &Error{Op: "ddds", Data: aus, Err: ErrNotFound, Msg: "key " + key}
+		}
+
+		key = canonicalName(next)
+	}
+
+	return nil, &Error{Op: "ddds", Data: aus, Err: ErrRewriteLoop, Msg: "too many rewrites"}
+}
+
+// apply applies the records of a key to the application unique string,
+// returning either the locations of terminal records or the key of a
+// non-terminal record.
+func (r *DDDSResolver) apply(ctx context.Context, records []NAPTR, aus string) ([]*url.URL, string, error) {
+	records = append([]NAPTR(nil), records...)
+
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].Order != records[j].Order {
+			return records[i].Order < records[j].Order
+		}
+
+		return records[i].Preference < records[j].Preference
+	})
+
+	for i := 0; i < len(records); {
+		// Records [i, j) have the same order.
+		j := i + 1
+		for j < len(records) && records[j].Order == records[i].Order {
+			j++
+		}
+
+		var (
+			locs []*url.URL
+			next string
+		)
+
+		for _, rec := range records[i:j] {
+			proto, ok := naptrServices(rec.Services)
+			if !ok {
+				continue
+			}
+
+			result, ok := naptrRewrite(rec, aus)
+			if !ok {
+				continue
+			}
+
+			switch strings.ToUpper(rec.Flags) {
+			case "":
+				if next == "" {
+					next = result
+				}
+			case "U":
+				if u, err := url.Parse(result); err == nil && u.IsAbs() {
+					locs = append(locs, u)
+				}
+			case "S":
+				srvs, err := r.DNS.LookupSRV(ctx, result)
+				if err != nil {
+					return nil, "", &Error{Op: "ddds", Data: aus, Err: err, Msg: "SRV " + result}
+				}
+
+				sortSRV(srvs)
+
+				for _, srv := range srvs {
+					if u := thttpURL(proto, srv.Target, srv.Port, aus); u != nil {
+						locs = append(locs, u)
+					}
+				}
+			case "A":
+				if u := thttpURL(proto, result, 0, aus); u != nil {
+					locs = append(locs, u)
+				}
+			}
+		}
+
+		// Terminal results take precedence over the non-terminal
+		// records of the same order.
+		if len(locs) > 0 {
+			return locs, "", nil
+		}
+
+		if next != "" {
+			return nil, next, nil
+		}
+
+		i = j
+	}
+
+	return nil, "", nil
+}
+
+// naptrServices reports whether the services field of a record allows
+// resolving locations, and returns its protocol.
+//
+//	service-field = [ [protocol] *("+" rs)]
+func naptrServices(s string) (string, bool) {
+	if s == "" {
+		return "", true
+	}
+
+	fields := strings.Split(s, "+")
+
+	for _, rs := range fields[1:] {
+		switch Service(rs) {
+		case N2L, N2Ls, I2L, I2Ls:
+			return strings.ToLower(fields[0]), true
+		}
+	}
+
+	return "", false
+}
+
+// naptrRewrite returns the result of the record applied to the
+// application unique string: the substitution expression, if any, or
+// the replacement.
+func naptrRewrite(rec NAPTR, aus string) (string, bool) {
+	if rec.Regexp == "" {
+		if rec.Replacement == "" || rec.Replacement == "." {
+			return "", false
+		}
+
+		return rec.Replacement, true
+	}
+
+	re, repl, ok := parseNAPTRRegexp(rec.Regexp)
+	if !ok {
+		return "", false
+	}
+
+	m := re.FindStringSubmatchIndex(aus)
+	if m == nil {
+		return "", false
+	}
+
+	return expandNAPTR(repl, aus, m), true
+}
+
+// parseNAPTRRegexp parses a substitution expression, and reports
+// whether it is valid:
+//
+//	subst-expr   = delim-char  ere  delim-char  repl  delim-char  *flags
+func parseNAPTRRegexp(s string) (*regexp.Regexp, string, bool) {
+	if len(s) < 3 {
+		return nil, "", false
+	}
+
+	delim := s[0]
+	if isDigit(delim) || delim == '\\' || delim == 'i' {
+		return nil, "", false
+	}
+
+	var parts []string
+
+	start := 1
+
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case delim:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+
+	if len(parts) != 2 {
+		return nil, "", false
+	}
+
+	ere, repl, flags := parts[0], parts[1], s[start:]
+
+	// An escaped delimiter stands for itself.
+	ere = strings.ReplaceAll(ere, `\`+string(delim), string(delim))
+	repl = strings.ReplaceAll(repl, `\`+string(delim), string(delim))
+
+	switch flags {
+	case "":
+	case "i":
+		ere = "(?i)" + ere
+	default:
+		return nil, "", false
+	}
+
+	re, err := regexp.Compile(ere)
+	if err != nil {
+		return nil, "", false
+	}
+
+	return re, repl, true
+}
+
+// expandNAPTR expands the back-references \1 to \9 of repl with the
+// submatches m of s.
+func expandNAPTR(repl, s string, m []int) string {
+	var b strings.Builder
+
+	for i := 0; i < len(repl); i++ {
+		c := repl[i]
+		if c != '\\' || i+1 == len(repl) {
+			b.WriteByte(c)
+
+			continue
+		}
+
+		i++
+
+		if n := int(repl[i] - '0'); repl[i] >= '1' && repl[i] <= '9' {
+			if 2*n+1 < len(m) && m[2*n] >= 0 {
+				b.WriteString(s[m[2*n]:m[2*n+1]])
+			}
+
+			continue
+		}
+
+		b.WriteByte(repl[i])
+	}
+
+	return b.String()
+}
+
+// sortSRV sorts SRV records by priority, and then by descending weight.
+func sortSRV(srvs []*net.SRV) {
+	sort.SliceStable(srvs, func(i, j int) bool {
+		if srvs[i].Priority != srvs[j].Priority {
+			return srvs[i].Priority < srvs[j].Priority
+		}
+
+		return srvs[i].Weight > srvs[j].Weight
+	})
+}
+
+// thttpURL returns the URL of the N2L service of a THTTP resolver at
+// host, or nil if the protocol is not HTTP.
+func thttpURL(proto, host string, port uint16, aus string) *url.URL {
+	var defaultPort uint16
+
+	switch proto {
+	case "http":
+		defaultPort = 80
+	case "https":
+		defaultPort = 443
+	default:
+		return nil
+	}
+
+	host = strings.TrimSuffix(host, ".")
+	if host == "" {
+		return nil
+	}
+
+	if port != 0 && port != defaultPort {
+		host = net.JoinHostPort(host, strconv.Itoa(int(port)))
+	}
+
+	return &url.URL{
+		Scheme:   proto,
+		Host:     host,
+		Path:     "/uri-res/" + string(N2L),
+		RawQuery: aus,
+	}
+}
@@ -0,0 +1,265 @@
+package urn_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/paulourio/go-urn"
+	"github.com/stretchr/testify/assert"
+)
+
+func testZone() *urn.Zone {
+	z := urn.NewZone()
+
+	// Delegation from the first well known rule.
+	z.AddNAPTR("isbn.urn.arpa.", urn.NAPTR{
+		Order: 100, Preference: 10, Replacement: "ISBN.example.",
+	})
+	z.AddNAPTR("isbn.example",
+		urn.NAPTR{
+			Order: 200, Preference: 10, Flags: "u", Services: "http+N2L",
+			Regexp: "!.*!https://never.example/!",
+		},
+		urn.NAPTR{
+			Order: 100, Preference: 20, Flags: "s", Services: "http+N2L+N2C",
+			Replacement: "_http._tcp.isbn.example.",
+		},
+		urn.NAPTR{
+			Order: 100, Preference: 10, Flags: "u", Services: "http+N2L+N2Ls",
+			Regexp: `!^urn:isbn:(.*)$!https://books.example/isbn/\1!i`, Replacement: ".",
+		},
+		urn.NAPTR{
+			Order: 100, Preference: 5, Flags: "p", Services: "z3950+N2L",
+			Replacement: "z3950.isbn.example.",
+		},
+		urn.NAPTR{
+			Order: 100, Preference: 1, Flags: "u", Services: "http+N2C",
+			Regexp: "!.*!https://metadata.example/!",
+		},
+	)
+	z.AddSRV("_http._tcp.isbn.example.",
+		&net.SRV{Target: "backup.isbn.example.", Port: 80, Priority: 20, Weight: 1},
+		&net.SRV{Target: "res.isbn.example.", Port: 8080, Priority: 10, Weight: 1},
+	)
+
+	// Host name of a THTTP resolver.
+	z.AddNAPTR("ietf.urn.arpa.", urn.NAPTR{
+		Order: 10, Preference: 10, Flags: "a", Services: "https+N2L",
+		Replacement: "resolver.ietf.example.",
+	})
+
+	// Non-terminal regexp rewrite, then a delegation.
+	z.AddNAPTR("example.urn.arpa.", urn.NAPTR{
+		Order: 10, Preference: 10,
+		Regexp: `#^urn:example:([a-z]+):.*$#\1.example.#`,
+	})
+	z.AddNAPTR("docs.example.", urn.NAPTR{
+		Order: 10, Preference: 10, Flags: "U", Services: "https+N2L",
+		Regexp: `!^urn:example:docs:(.*)$!https://docs.example/\1?from=\\!`,
+	})
+
+	// Loops and dead ends.
+	z.AddNAPTR("loop.urn.arpa.", urn.NAPTR{Order: 10, Replacement: "loop2.example."})
+	z.AddNAPTR("loop2.example.", urn.NAPTR{Order: 10, Replacement: "LOOP.urn.arpa"})
+	z.AddNAPTR("bad.urn.arpa.",
+		urn.NAPTR{Order: 10, Flags: "u", Services: "http+N2L", Regexp: "!(!x!"},
+		urn.NAPTR{Order: 10, Flags: "u", Services: "http+N2L", Regexp: "1.*1x1"},
+		urn.NAPTR{Order: 10, Flags: "u", Services: "http+N2L", Regexp: "!.*!x!"},
+		urn.NAPTR{Order: 10, Flags: "x", Services: "http+N2L", Replacement: "x.example."},
+	)
+
+	return z
+}
+
+func TestDDDSResolver(t *testing.T) {
+	t.Parallel()
+
+	r := urn.NewDDDSResolver(testZone())
+
+	cases := []struct {
+		Service   urn.Service
+		Input     string
+		Locations []string
+		Err       error
+	}{
+		{
+			Service: urn.N2Ls,
+			Input:   "urn:isbn:0451450523?+lang=en",
+			Locations: []string{
+				"https://books.example/isbn/0451450523",
+				"http://res.isbn.example:8080/uri-res/N2L?urn:isbn:0451450523",
+				"http://backup.isbn.example/uri-res/N2L?urn:isbn:0451450523",
+			},
+		},
+		{
+			Service:   urn.I2L,
+			Input:     "URN:ISBN:0451450523",
+			Locations: []string{"https://books.example/isbn/0451450523"},
+		},
+		{
+			Service:   urn.N2L,
+			Input:     "urn:ietf:rfc:2648",
+			Locations: []string{"https://resolver.ietf.example/uri-res/N2L?urn:ietf:rfc:2648"},
+		},
+		{
+			Service:   urn.N2L,
+			Input:     "urn:example:docs:a/b",
+			Locations: []string{`https://docs.example/a/b?from=\`},
+		},
+		{Service: urn.N2L, Input: "urn:example:other:a", Err: urn.ErrNotFound},
+		{Service: urn.N2L, Input: "urn:unknown:a", Err: urn.ErrNotFound},
+		{Service: urn.N2L, Input: "urn:bad:a", Err: urn.ErrNotFound},
+		{Service: urn.N2R, Input: "urn:isbn:0451450523", Err: urn.ErrUnsupportedService},
+	}
+
+	for i, c := range cases {
+		msg := fmt.Sprintf("case %d: %s %q", i+1, c.Service, c.Input)
+
+		id, err := urn.Parse(c.Input)
+		if !assert.NoError(t, err, msg) {
+			continue
+		}
+
+		resp, err := r.Resolve(context.Background(), urn.NewRequest(c.Service, id))
+		if c.Err != nil {
+			assert.ErrorIs(t, err, c.Err, msg)
+
+			continue
+		}
+
+		if assert.NoError(t, err, msg) {
+			assert.Equal(t, c.Locations, locationStrings(resp.Locations), msg)
+		}
+	}
+
+	_, err := r.Resolve(context.Background(), &urn.Request{Service: urn.N2L, URI: "urn:loop:x", URN: &urn.URN{
+		Scheme: "urn", NID: "loop", NSS: "x",
+	}})
+	assert.ErrorIs(t, err, urn.ErrRewriteLoop)
+
+	_, err = r.Resolve(context.Background(), &urn.Request{Service: urn.I2L, URI: "https://example.com/"})
+	assert.ErrorIs(t, err, urn.ErrUnsupportedService)
+}
+
+// failingDNS fails all lookups.
+type failingDNS struct{}
+
+var errDNS = errors.New("test: server failure")
+
+func (failingDNS) LookupNAPTR(context.Context, string) ([]urn.NAPTR, error) {
+	return nil, errDNS
+}
+
+func (failingDNS) LookupSRV(context.Context, string) ([]*net.SRV, error) {
+	return nil, errDNS
+}
+
+// failingSRV fails the SRV lookups of a zone.
+type failingSRV struct {
+	*urn.Zone
+}
+
+func (failingSRV) LookupSRV(context.Context, string) ([]*net.SRV, error) {
+	return nil, errDNS
+}
+
+func TestDDDSResolverErrors(t *testing.T) {
+	t.Parallel()
+
+	srv := urn.NewZone()
+	srv.AddNAPTR("isbn.urn.arpa.", urn.NAPTR{Flags: "s", Services: "http+N2L", Replacement: "_http._tcp.isbn.example."})
+
+	cases := []struct {
+		DNS     urn.DNS
+		Service urn.Service
+		Input   string
+		Err     error
+		Message string
+	}{
+		{
+			DNS:     testZone(),
+			Service: urn.N2L,
+			Input:   "urn:loop:x",
+			Err:     urn.ErrRewriteLoop,
+			Message: `ddds "urn:loop:x": rewrite loop: key loop.urn.arpa.`,
+		},
+		{
+			DNS:     testZone(),
+			Service: urn.N2L,
+			Input:   "urn:unknown:x",
+			Err:     urn.ErrNotFound,
+			Message: `ddds "urn:unknown:x": not found: key unknown.urn.arpa.`,
+		},
+		{
+			DNS:     testZone(),
+			Service: urn.N2R,
+			Input:   "urn:isbn:1",
+			Err:     urn.ErrUnsupportedService,
+			Message: `ddds "urn:isbn:1": unsupported service: service N2R`,
+		},
+		{
+			DNS:     failingDNS{},
+			Service: urn.N2L,
+			Input:   "URN:ISBN:1",
+			Err:     errDNS,
+			Message: `ddds "urn:isbn:1": test: server failure: NAPTR isbn.urn.arpa.`,
+		},
+		{
+			DNS:     failingSRV{srv},
+			Service: urn.N2L,
+			Input:   "urn:isbn:1",
+			Err:     errDNS,
+			Message: `ddds "urn:isbn:1": test: server failure: SRV _http._tcp.isbn.example.`,
+		},
+	}
+
+	for i, c := range cases {
+		msg := fmt.Sprintf("case %d: %s %q", i+1, c.Service, c.Input)
+
+		id, err := urn.Parse(c.Input)
+		if !assert.NoError(t, err, msg) {
+			continue
+		}
+
+		_, err = urn.NewDDDSResolver(c.DNS).Resolve(context.Background(), urn.NewRequest(c.Service, id))
+
+		var uerr *urn.Error
+		if assert.ErrorAs(t, err, &uerr, msg) {
+			assert.ErrorIs(t, err, c.Err, msg)
+			assert.Equal(t, c.Message, uerr.Error(), msg)
+		}
+	}
+}
+
+func TestDDDSResolverMux(t *testing.T) {
+	t.Parallel()
+
+	m := urn.NewResolverMux()
+	m.HandleDefault(urn.NewDDDSResolver(testZone()))
+
+	resp, err := m.Resolve(context.Background(), &urn.Request{Service: urn.N2L, URI: "urn:isbn:0451450523"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"https://books.example/isbn/0451450523"}, locationStrings(resp.Locations))
+	}
+}
+
+func TestDDDSSuffix(t *testing.T) {
+	t.Parallel()
+
+	z := urn.NewZone()
+	z.AddNAPTR("isbn.urn.test.", urn.NAPTR{
+		Flags: "u", Services: "https+N2L", Regexp: "!^.*$!https://test.example/!",
+	})
+
+	r := &urn.DDDSResolver{DNS: z, Suffix: "urn.test"}
+
+	resp, err := r.Resolve(context.Background(), &urn.Request{
+		Service: urn.N2L, URN: &urn.URN{Scheme: "urn", NID: "isbn", NSS: "1"},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "https://test.example/", resp.Location().String())
+	}
+}
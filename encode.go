@@ -1,34 +1,75 @@
 package urn
 
 import (
-	"strings"
+	"fmt"
 )
 
-// Decode unescapes a string and returns a byte slice.
+// Decode unescapes a string and returns a byte slice.  A '%' that does
+// not start a valid percent-encoding is kept as is; use DecodeString to
+// reject it.
 func Decode(d string) []byte {
-	count := strings.Count(d, "%")
-	data := make([]byte, len(d)-count*2)
+	data := make([]byte, 0, len(d))
 	n := len(d)
-	i := 0
-	pos := 0
 
-	for i < n {
+	for i := 0; i < n; i++ {
 		c := d[i]
 
-		if i+2 < n && c == '%' && isHex(d[i+1]) && isHex(d[i+2]) {
-			data[pos] = unhex(d[i+1])<<4 + unhex(d[i+2])
-			i += 3
-			pos++
-		} else {
-			data[pos] = c
-			i++
-			pos++
+		if c == '%' && i+2 < n && isHex(d[i+1]) && isHex(d[i+2]) {
+			c = unhex(d[i+1])<<4 | unhex(d[i+2])
+			i += 2
 		}
+
+		data = append(data, c)
 	}
 
 	return data
 }
 
+// DecodeString unescapes a string.  It fails if a '%' is not followed
+// by two hexadecimal digits.
+func DecodeString(s string) (string, error) {
+	if i := invalidEscape(s); i >= 0 {
+		return "", escapeError("decode", s, i)
+	}
+
+	return string(Decode(s)), nil
+}
+
+// escapeError returns the error of the invalid escape at offset i of s.
+func escapeError(op, s string, i int) error {
+	length := len(s) - i
+	if length > 3 {
+		length = 3
+	}
+
+	return &Error{
+		Op:     op,
+		Data:   s,
+		Err:    ErrInvalidEncoding,
+		Msg:    fmt.Sprintf("invalid escape %q", s[i:i+length]),
+		Offset: i,
+		Length: length,
+	}
+}
+
+// invalidEscape returns the offset of the first '%' of s not followed by
+// two hexadecimal digits, or -1 if there is none.
+func invalidEscape(s string) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' {
+			continue
+		}
+
+		if i+2 >= len(s) || !isHex(s[i+1]) || !isHex(s[i+2]) {
+			return i
+		}
+
+		i += 2
+	}
+
+	return -1
+}
+
 // EncodeStringComponent escapes a string so that it is suitable for use
 // as a Resolve, Query, or Fragment component of a URN.
 func EncodeStringComponent(d string) string {
@@ -75,6 +116,7 @@ func RecodeStringNSS(d string) string {
 // one component of a URN.
 type Encoder struct {
 	Allowed []byte // additional single bytes that should not escape
+	Escaped []byte // additional single bytes that should escape
 }
 
 // Encode escapes bytes that are reserved for URI syntax, according to
@@ -88,6 +130,7 @@ func (e *Encoder) Encode(d []byte) string {
 		input: d,
 		n:     len(d),
 		allow: e.Allowed,
+		deny:  e.Escaped,
 	}
 
 	size := impl.computeOutputSize()
@@ -121,11 +164,22 @@ func WithKeepUnescaped(b ...byte) func(*Encoder) {
 	}
 }
 
+// WithEscaped defines a list of single bytes that should be escaped by
+// an encoder, even if they are otherwise allowed in a component.  This
+// is useful to escape delimiters of a component's inner syntax, such as
+// '&' and '=' in a q-component of key/value pairs.
+func WithEscaped(b ...byte) func(*Encoder) {
+	return func(e *Encoder) {
+		e.Escaped = append(e.Escaped, b...)
+	}
+}
+
 type escaper struct {
 	// Input
 	input []byte
 	n     int
 	allow []byte
+	deny  []byte
 
 	// Working data
 	data []byte
@@ -161,6 +215,12 @@ func (e *escaper) computeOutputSize() (sz int) {
 }
 
 func (e *escaper) shouldEscape(c byte) bool {
+	for _, b := range e.deny {
+		if c == b {
+			return true
+		}
+	}
+
 	if isPCharSingle(c) {
 		return false
 	}
@@ -56,6 +56,44 @@ func TestDecode(t *testing.T) {
 	}
 }
 
+func TestDecodeString(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Input  string
+		Output string
+		Offset int
+		Length int
+	}{
+		{Input: "", Output: ""},
+		{Input: "a%20b%2F", Output: "a b/"},
+		{Input: "100%", Offset: 3, Length: 1},
+		{Input: "a%2", Offset: 1, Length: 2},
+		{Input: "%zz", Offset: 0, Length: 3},
+		{Input: "%41%4g", Offset: 3, Length: 3},
+	}
+
+	for i, c := range cases {
+		msg := fmt.Sprintf("case %d: %q", i+1, c.Input)
+
+		out, err := urn.DecodeString(c.Input)
+		if c.Length == 0 {
+			assert.NoError(t, err, msg)
+			assert.Equal(t, c.Output, out, msg)
+
+			continue
+		}
+
+		var uerr *urn.Error
+		if assert.ErrorAs(t, err, &uerr, msg) {
+			assert.ErrorIs(t, err, urn.ErrInvalidEncoding, msg)
+			assert.Equal(t, "decode", uerr.Op, msg)
+			assert.Equal(t, c.Offset, uerr.Offset, msg)
+			assert.Equal(t, c.Length, uerr.Length, msg)
+		}
+	}
+}
+
 type EncodingOp int
 
 const (
@@ -104,6 +142,11 @@ var encodingCases = []*encodingCase{
 	{Op: Decode, String: "@!=%2C(xyz)+a,b.*@g=$_", Decode: []byte("@!=,(xyz)+a,b.*@g=$_")},
 	{Op: Decode, String: "%20", Decode: []byte(" ")},
 	{Op: Decode, String: "%41%00%1A", Decode: []byte{0x41, 0x0, 0x1a}},
+	// Invalid escapes are kept as is.
+	{Op: Decode, String: "100%", Decode: []byte("100%")},
+	{Op: Decode, String: "a%2", Decode: []byte("a%2")},
+	{Op: Decode, String: "%zz%41", Decode: []byte("%zzA")},
+	{Op: Decode, String: "%%41", Decode: []byte("%A")},
 	// Encode
 	{Op: EncodeComp, String: "", Encode: ""},
 	{Op: EncodeNSS, String: "", Encode: ""},
@@ -128,3 +171,10 @@ var encodingCases = []*encodingCase{
 	{Op: RecodeNSS, String: "%32%33%34", Encode: "234"},
 	{Op: RecodeNSS, String: "%32%33%34%3f", Encode: "234%3F"},
 }
+
+func TestEncoderWithEscaped(t *testing.T) {
+	t.Parallel()
+
+	e := urn.NewEncoder(urn.WithKeepUnescaped('/'), urn.WithEscaped('&', '=', '/'))
+	assert.Equal(t, "a%3Db%26c%2Fd%3F", e.Encode([]byte("a=b&c/d?")))
+}
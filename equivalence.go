@@ -1,5 +1,11 @@
 package urn
 
+import (
+	"hash/fnv"
+	"io"
+	"strings"
+)
+
 type ComparisonPart int
 
 const (
@@ -63,6 +69,20 @@ const (
 	//   be normalized by decoding any percent-encoded octet that corresponds
 	//   to an unreserved character, as described in Section 2.3.
 	EncodingNormalized
+	// NamespaceNormalized is the case-normalization procedure followed
+	// by the lexical equivalence rules of the URN namespace, as found
+	// in the DefaultRegistry.  NSSs of unknown namespaces, or that are
+	// not valid in their namespace, are only case-normalized.
+	//
+	// [RFC 8141 §3.1](urn:ietf:rfc:8141#section-3.1):
+	//
+	//   URN namespaces MAY define additional rules for lexical
+	//   equivalence, such as case-insensitivity of the NSS (or parts
+	//   thereof).  Such rules MUST always have the effect of eliminating
+	//   some of the false negatives obtained by the procedure above and
+	//   MUST NOT result in treating two URNs as not being equivalent if
+	//   the procedure here says they are URN-equivalent.
+	NamespaceNormalized
 )
 
 // EqualString returns whether the string representations of the two URNs
@@ -84,23 +104,80 @@ func Equal(a *URN, b *URN, part ComparisonPart, method ComparisonMethod) bool {
 		return true
 	}
 
+	a = a.NormalizedBy(method)
+	b = b.NormalizedBy(method)
+
+	switch part {
+	case AssignedName:
+		return a.AssignedName() == b.AssignedName()
+	case AllParts:
+		return a.String() == b.String()
+	}
+
+	panic("unexpected equal params")
+}
+
+// NormalizedBy returns the identifier normalized with the procedure of
+// the comparison method, without changing contents of the current
+// identifier.  For Simple, it returns the identifier itself.
+func (u *URN) NormalizedBy(method ComparisonMethod) *URN {
 	switch method {
-	case Simple:
-		// No transformation required.
 	case CaseNormalized:
-		a = a.Normalized()
-		b = b.Normalized()
+		return u.Normalized()
 	case EncodingNormalized:
-		a = a.EncodingNormalized()
-		b = b.EncodingNormalized()
+		return u.EncodingNormalized()
+	case NamespaceNormalized:
+		return u.NamespaceNormalized()
 	}
 
+	return u
+}
+
+// Key returns the string that Equal compares for the part and method:
+// the normalized assigned name or complete identifier.  Two URNs are
+// equal under Equal exactly when their keys are equal, so keys can be
+// used to deduplicate URNs, as map keys:
+//
+//	seen := map[string]bool{}
+//	seen[urn.Key(u, urn.AssignedName, urn.CaseNormalized)] = true
+func Key(u *URN, part ComparisonPart, method ComparisonMethod) string {
+	if u == nil {
+		return ""
+	}
+
+	u = u.NormalizedBy(method)
+
 	switch part {
 	case AssignedName:
-		return a.AssignedName() == b.AssignedName()
+		return u.AssignedName()
 	case AllParts:
-		return a.String() == b.String()
+		return u.String()
 	}
 
-	panic("unexpected equal params")
+	panic("unexpected key params")
+}
+
+// Compare returns an integer comparing two URNs by their keys, for the
+// part and method.  The result is 0 if Equal(a, b, part, method), -1 if
+// a sorts before b, and +1 otherwise.  URNs are ordered bytewise by key,
+// which gives a total order consistent with Equal:
+//
+//	sort.Slice(ids, func(i, j int) bool {
+//		return urn.Compare(ids[i], ids[j], urn.AssignedName, urn.CaseNormalized) < 0
+//	})
+func Compare(a *URN, b *URN, part ComparisonPart, method ComparisonMethod) int {
+	if a == b {
+		return 0
+	}
+
+	return strings.Compare(Key(a, part, method), Key(b, part, method))
+}
+
+// Hash returns the 64-bit FNV-1a hash of the key of the URN, for the
+// part and method.  Equal URNs have equal hashes.
+func Hash(u *URN, part ComparisonPart, method ComparisonMethod) uint64 {
+	h := fnv.New64a()
+	_, _ = io.WriteString(h, Key(u, part, method))
+
+	return h.Sum64()
 }
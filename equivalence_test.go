@@ -0,0 +1,162 @@
+package urn_test
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/paulourio/go-urn"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	comparisonParts   = []urn.ComparisonPart{urn.AssignedName, urn.AllParts}
+	comparisonMethods = []urn.ComparisonMethod{
+		urn.Simple, urn.CaseNormalized, urn.EncodingNormalized, urn.NamespaceNormalized,
+	}
+)
+
+// quickURN generates URNs from small alphabets of equivalent spellings,
+// so that random pairs are often equal under some method.
+type quickURN struct {
+	*urn.URN
+}
+
+func (quickURN) Generate(r *rand.Rand, size int) reflect.Value {
+	pick := func(s ...string) string { return s[r.Intn(len(s))] }
+
+	var b strings.Builder
+
+	b.WriteString(pick("urn", "URN", "Urn"))
+	b.WriteByte(':')
+	b.WriteString(pick("example", "EXAMPLE", "isbn", "ISBN", "uuid"))
+	b.WriteByte(':')
+
+	for i := r.Intn(3); i >= 0; i-- {
+		b.WriteString(pick("a", "A", "%61", "%2f", "%2F", "0-395-36341-1", "9780395363416",
+			"F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6", "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"))
+	}
+
+	if r.Intn(3) == 0 {
+		b.WriteString("?+" + pick("r", "R", "%72"))
+	}
+
+	if r.Intn(3) == 0 {
+		b.WriteString("?=" + pick("q", "Q", "%71"))
+	}
+
+	if r.Intn(3) == 0 {
+		b.WriteString("#" + pick("", "f", "%66"))
+	}
+
+	u, err := urn.Parse(b.String())
+	if err != nil {
+		panic(err)
+	}
+
+	return reflect.ValueOf(quickURN{u})
+}
+
+func TestKeyProperties(t *testing.T) {
+	t.Parallel()
+
+	equalPairs := 0
+
+	prop := func(a, b quickURN) bool {
+		for _, part := range comparisonParts {
+			for _, method := range comparisonMethods {
+				eq := urn.Equal(a.URN, b.URN, part, method)
+				if eq {
+					equalPairs++
+				}
+
+				ka, kb := urn.Key(a.URN, part, method), urn.Key(b.URN, part, method)
+				cmp := urn.Compare(a.URN, b.URN, part, method)
+
+				if eq != (ka == kb) ||
+					eq != (cmp == 0) ||
+					cmp != -urn.Compare(b.URN, a.URN, part, method) ||
+					eq != (urn.Hash(a.URN, part, method) == urn.Hash(b.URN, part, method)) {
+					t.Logf("a=%v b=%v part=%d method=%d", a.URN, b.URN, part, method)
+
+					return false
+				}
+			}
+		}
+
+		return true
+	}
+
+	assert.NoError(t, quick.Check(prop, &quick.Config{MaxCount: 2000}))
+	assert.NotZero(t, equalPairs, "generator produced no equal pairs")
+}
+
+func TestCompareTransitive(t *testing.T) {
+	t.Parallel()
+
+	prop := func(a, b, c quickURN) bool {
+		for _, part := range comparisonParts {
+			for _, method := range comparisonMethods {
+				ab := urn.Compare(a.URN, b.URN, part, method)
+				bc := urn.Compare(b.URN, c.URN, part, method)
+				ac := urn.Compare(a.URN, c.URN, part, method)
+
+				if ab <= 0 && bc <= 0 && ac > 0 {
+					return false
+				}
+			}
+		}
+
+		return true
+	}
+
+	assert.NoError(t, quick.Check(prop, &quick.Config{MaxCount: 1000}))
+}
+
+func TestCompareSort(t *testing.T) {
+	t.Parallel()
+
+	var ids []*urn.URN
+
+	for _, s := range []string{
+		"urn:example:b",
+		"URN:EXAMPLE:a%2f",
+		"urn:example:a%2F",
+		"urn:example:a",
+		"urn:Example:a?=q",
+	} {
+		u, err := urn.Parse(s)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		ids = append(ids, u)
+	}
+
+	sort.SliceStable(ids, func(i, j int) bool {
+		return urn.Compare(ids[i], ids[j], urn.AssignedName, urn.CaseNormalized) < 0
+	})
+
+	var got []string
+
+	for _, u := range ids {
+		got = append(got, u.String())
+	}
+
+	assert.Equal(t, []string{
+		"urn:example:a",
+		"urn:Example:a?=q",
+		"URN:EXAMPLE:a%2f",
+		"urn:example:a%2F",
+		"urn:example:b",
+	}, got)
+
+	assert.Equal(t, 0, urn.Compare(nil, nil, urn.AllParts, urn.Simple))
+	assert.Equal(t, "", urn.Key(nil, urn.AllParts, urn.Simple))
+	assert.Equal(t,
+		urn.Hash(ids[2], urn.AssignedName, urn.CaseNormalized),
+		urn.Hash(ids[3], urn.AssignedName, urn.CaseNormalized))
+}
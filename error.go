@@ -3,6 +3,9 @@ package urn
 import (
 	"errors"
 	"fmt"
+	"io"
+	"strings"
+	"unicode"
 )
 
 // Error represents an error that occurred during an operation, such
@@ -12,6 +15,14 @@ type Error struct {
 	Data string // Data associated with the error, eg URN or component
 	Err  error
 	Msg  string // Optional explanation
+
+	// Position of the error in Data.  Errors without a position have
+	// an unknown Component and zero Offset and Length.  Length may be
+	// zero when the error is at the end of Data, such as when the input
+	// is truncated.
+	Component Component // Component where the error was found
+	Offset    int       // Byte offset of the offending sequence
+	Length    int       // Length in bytes of the offending sequence
 }
 
 func (e *Error) Error() string {
@@ -26,6 +37,68 @@ func (e *Error) Unwrap() error {
 	return e.Err
 }
 
+// Pretty returns the error message followed by the data with a caret
+// under the offending sequence, as in:
+//
+//	parse "urn:a~a:b": invalid NID: invalid byte at pos 5
+//	  urn:a~a:b
+//	       ^
+//
+// Errors without a position return only the message.
+func (e *Error) Pretty() string {
+	var b strings.Builder
+
+	b.WriteString(e.Error())
+
+	if !e.hasPosition() || e.Offset < 0 || e.Offset > len(e.Data) {
+		return b.String()
+	}
+
+	b.WriteString("\n  ")
+
+	for _, r := range e.Data {
+		if unicode.IsControl(r) {
+			r = '.'
+		}
+
+		b.WriteRune(r)
+	}
+
+	b.WriteString("\n  ")
+	b.WriteString(strings.Repeat(" ", len([]rune(e.Data[:e.Offset]))))
+	b.WriteByte('^')
+
+	end := e.Offset + e.Length
+	if end > len(e.Data) {
+		end = len(e.Data)
+	}
+
+	if n := len([]rune(e.Data[e.Offset:end])); n > 1 {
+		b.WriteString(strings.Repeat("~", n-1))
+	}
+
+	return b.String()
+}
+
+// hasPosition reports whether the error locates the offending sequence,
+// which it does if it names the component or sets Offset or Length.
+func (e *Error) hasPosition() bool {
+	return e.Component != ComponentUnknown || e.Offset != 0 || e.Length != 0
+}
+
+// Format implements fmt.Formatter.  The %+v verb renders the error
+// with Pretty, and other verbs behave as for the message.
+func (e *Error) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('+'):
+		_, _ = io.WriteString(f, e.Pretty())
+	case verb == 'q':
+		fmt.Fprintf(f, "%q", e.Error())
+	default:
+		_, _ = io.WriteString(f, e.Error())
+	}
+}
+
 var (
 	ErrInvalidIdentifier = errors.New("invalid identifier")
 	ErrInvalidScheme     = errors.New("invalid scheme")
@@ -33,4 +106,19 @@ var (
 	ErrInvalidNSS        = errors.New("invalid NSS")
 	ErrInvalidResolve    = errors.New("invalid resolve component")
 	ErrInvalidQuery      = errors.New("invalid query component")
+	ErrInvalidEncoding   = errors.New("invalid percent-encoding")
+	ErrInvalidTemplate   = errors.New("invalid template")
+	ErrTimeOutOfRange    = errors.New("time out of range")
+
+	ErrDuplicateNamespace = errors.New("duplicate namespace")
+)
+
+// Errors of resolution.
+var (
+	ErrNoResolver         = errors.New("no resolver")
+	ErrUnsupportedService = errors.New("unsupported service")
+	ErrNotFound           = errors.New("not found")
+	ErrDuplicateResolver  = errors.New("duplicate resolver")
+	ErrRewriteLoop        = errors.New("rewrite loop")
+	ErrResolverPanic      = errors.New("resolver panicked")
 )
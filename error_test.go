@@ -0,0 +1,87 @@
+package urn_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/paulourio/go-urn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorPosition(t *testing.T) {
+	t.Parallel()
+
+	for i, c := range errorPositionCases {
+		msg := fmt.Sprintf("case %d: %q", i+1, c.Input)
+
+		var uerr *urn.Error
+
+		_, err := urn.Parse(c.Input)
+		if assert.ErrorAs(t, err, &uerr, msg) {
+			assert.Equal(t, c.Component, uerr.Component, msg)
+			assert.Equal(t, c.Offset, uerr.Offset, msg)
+			assert.Equal(t, c.Length, uerr.Length, msg)
+		}
+	}
+}
+
+func TestErrorPretty(t *testing.T) {
+	t.Parallel()
+
+	_, err := urn.Parse("urn:a~a:b")
+	assert.Equal(t, `parse "urn:a~a:b": invalid NID: invalid byte at pos 5
+  urn:a~a:b
+       ^`, fmt.Sprintf("%+v", err))
+	assert.Equal(t, `parse "urn:a~a:b": invalid NID: invalid byte at pos 5`, fmt.Sprintf("%v", err))
+
+	_, err = urn.Parse("urn:abc:déf?x")
+	assert.Equal(t, `parse "urn:abc:déf?x": invalid NSS: trailing characters
+  urn:abc:déf?x
+           ^~~~`, err.(*urn.Error).Pretty())
+
+	_, err = urn.Parse("urn:abc")
+	assert.Equal(t, `parse "urn:abc": invalid NID: invalid final byte
+  urn:abc
+         ^`, err.(*urn.Error).Pretty())
+
+	_, err = urn.DefaultRegistry.Parse("urn:uuid:1234?=a")
+	assert.Equal(t, `validate "urn:uuid:1234?=a": invalid NSS: uuid: invalid length
+  urn:uuid:1234?=a
+           ^~~~`, err.(*urn.Error).Pretty())
+
+	// Errors of an unknown component still locate the sequence.
+	_, err = urn.Parse("urn:ab:x?#")
+	assert.Equal(t, `parse "urn:ab:x?#": invalid identifier: invalid sequence after '?'
+  urn:ab:x?#
+          ^~`, err.(*urn.Error).Pretty())
+
+	err = &urn.Error{Op: "test", Data: "a\tb", Err: urn.ErrInvalidNSS}
+	assert.Equal(t, `test "a\tb": invalid NSS`, err.(*urn.Error).Pretty())
+	assert.Equal(t, `"test \"a\\tb\": invalid NSS"`, fmt.Sprintf("%q", err))
+}
+
+type errorPositionTestCase struct {
+	Input     string
+	Component urn.Component
+	Offset    int
+	Length    int
+}
+
+var errorPositionCases = []*errorPositionTestCase{
+	{"ur", urn.ComponentScheme, 2, 0},
+	{"urx:a:b", urn.ComponentScheme, 0, 4},
+	{"urn:", urn.ComponentNID, 4, 0},
+	{"urn:-a:b", urn.ComponentNID, 4, 1},
+	{"urn:a~a:b", urn.ComponentNID, 5, 1},
+	{"urn:ab-:b", urn.ComponentNID, 6, 1},
+	{"urn:ab", urn.ComponentNID, 6, 0},
+	{"urn:a:b", urn.ComponentNID, 4, 1},
+	{"urn:ab:", urn.ComponentNSS, 7, 0},
+	{"urn:ab:/x", urn.ComponentNSS, 7, 1},
+	{"urn:ab:x y", urn.ComponentNSS, 8, 2},
+	{"urn:ab:x?+", urn.ComponentResolve, 10, 0},
+	{"urn:ab:x?+/", urn.ComponentResolve, 10, 1},
+	{"urn:ab:x?=", urn.ComponentQuery, 10, 0},
+	{"urn:ab:x?=?", urn.ComponentQuery, 10, 1},
+	{"urn:ab:x?#", urn.ComponentUnknown, 8, 2},
+}
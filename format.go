@@ -0,0 +1,66 @@
+package urn
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// Format implements fmt.Formatter.  The verbs are:
+//
+//	%v   the complete identifier, as String
+//	%s   the assigned name, as AssignedName
+//	%q   the complete identifier, quoted
+//	%+v  the components: {Scheme:urn NID:nid NSS:nss ...}
+//	%#v  Go syntax for the exported fields
+//
+// Width and precision apply to %v, %s and %q as they apply to strings.
+func (u *URN) Format(f fmt.State, verb rune) {
+	if u == nil {
+		_, _ = io.WriteString(f, "<nil>")
+
+		return
+	}
+
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		fmt.Fprintf(f, "&urn.URN{Scheme:%q, NID:%q, NSS:%q, Resolve:%q, Query:%q, Fragment:%q, ForceFragment:%t}",
+			u.Scheme, u.NID, u.NSS, u.Resolve, u.Query, u.Fragment, u.ForceFragment)
+	case verb == 'v' && f.Flag('+'):
+		fmt.Fprintf(f, "{Scheme:%s NID:%s NSS:%s Resolve:%s Query:%s Fragment:%s ForceFragment:%t}",
+			u.Scheme, u.NID, u.NSS, u.Resolve, u.Query, u.Fragment, u.ForceFragment)
+	case verb == 'v' || verb == 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), u.String())
+	case verb == 's':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), u.AssignedName())
+	default:
+		fmt.Fprintf(f, "%%!%c(*urn.URN=%s)", verb, u.String())
+	}
+}
+
+// LogValue implements slog.LogValuer.  The URN is logged as a group
+// of its NID, NSS, and the r-component, q-component and f-component
+// that are present.
+func (u *URN) LogValue() slog.Value {
+	if u == nil {
+		return slog.AnyValue(nil)
+	}
+
+	attrs := make([]slog.Attr, 2, 5)
+	attrs[0] = slog.String("nid", u.NID)
+	attrs[1] = slog.String("nss", u.NSS)
+
+	if u.Resolve != "" {
+		attrs = append(attrs, slog.String("resolve", u.Resolve))
+	}
+
+	if u.Query != "" {
+		attrs = append(attrs, slog.String("query", u.Query))
+	}
+
+	if u.Fragment != "" || u.ForceFragment {
+		attrs = append(attrs, slog.String("fragment", u.Fragment))
+	}
+
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,95 @@
+package urn_test
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/paulourio/go-urn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	id, err := urn.Parse("urn:ietf:rfc:8141?+Resolve=http?=foo=bar#sec")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	norm := id.Normalized()
+
+	cases := []struct {
+		Format string
+		Arg    interface{}
+		Output string
+	}{
+		{"%v", id, "urn:ietf:rfc:8141?+Resolve=http?=foo=bar#sec"},
+		{"%s", id, "urn:ietf:rfc:8141"},
+		{"%q", id, `"urn:ietf:rfc:8141?+Resolve=http?=foo=bar#sec"`},
+		{"%20s|", id, "   urn:ietf:rfc:8141|"},
+		{"%-20s|", id, "urn:ietf:rfc:8141   |"},
+		{"%.7s", id, "urn:iet"},
+		{
+			"%+v", id,
+			"{Scheme:urn NID:ietf NSS:rfc:8141 Resolve:Resolve=http Query:foo=bar Fragment:sec ForceFragment:false}",
+		},
+		{
+			"%#v", id,
+			`&urn.URN{Scheme:"urn", NID:"ietf", NSS:"rfc:8141", Resolve:"Resolve=http", ` +
+				`Query:"foo=bar", Fragment:"sec", ForceFragment:false}`,
+		},
+		// Normalization state is not exposed.
+		{"%#v", norm, fmt.Sprintf("%#v", id)},
+		{"%d", id, "%!d(*urn.URN=urn:ietf:rfc:8141?+Resolve=http?=foo=bar#sec)"},
+		{"%v", (*urn.URN)(nil), "<nil>"},
+		{"%s", (*urn.URN)(nil), "<nil>"},
+	}
+
+	for i, c := range cases {
+		msg := fmt.Sprintf("case %d: %q", i+1, c.Format)
+
+		assert.Equal(t, c.Output, fmt.Sprintf(c.Format, c.Arg), msg)
+	}
+}
+
+func TestLogValue(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Input  string
+		Output string
+	}{
+		{"urn:example:a", "id.nid=example id.nss=a"},
+		{"urn:example:a#", "id.nid=example id.nss=a id.fragment=\"\""},
+		{
+			"urn:ietf:rfc:8141?+r=1?=q=2#f",
+			`id.nid=ietf id.nss=rfc:8141 id.resolve="r=1" id.query="q=2" id.fragment=f`,
+		},
+	}
+
+	for i, c := range cases {
+		msg := fmt.Sprintf("case %d: %q", i+1, c.Input)
+
+		id, err := urn.Parse(c.Input)
+		if !assert.NoError(t, err, msg) {
+			continue
+		}
+
+		var buf bytes.Buffer
+
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey || a.Key == slog.MessageKey) {
+					return slog.Attr{}
+				}
+
+				return a
+			},
+		}))
+		logger.Info("", "id", id)
+
+		assert.Equal(t, c.Output+"\n", buf.String(), msg)
+	}
+}
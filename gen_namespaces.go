@@ -0,0 +1,180 @@
+//go:build ignore
+// +build ignore
+
+// This program generates namespaces_iana.go from a snapshot of the IANA
+// URN namespaces registry, the XML file checked into testdata.  Invoke
+// it with go generate after replacing the snapshot.
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+var (
+	input  = flag.String("input", "testdata/urn-namespaces.xml", "registry XML `file`")
+	output = flag.String("output", "namespaces_iana.go", "output `file`")
+)
+
+// Subregistries of the URN namespaces registry.
+const (
+	formalID   = "urn-namespaces-1"
+	informalID = "urn-namespaces-2"
+)
+
+type registry struct {
+	// Updated is the date of the registry.  Excerpts of the registry
+	// leave it out, which marks the snapshot as partial.
+	Updated    string        `xml:"updated"`
+	Registries []subregistry `xml:"registry"`
+	People     []person      `xml:"people>person"`
+}
+
+type subregistry struct {
+	ID      string   `xml:"id,attr"`
+	Records []record `xml:"record"`
+}
+
+type record struct {
+	Value  string `xml:"value"`
+	Status string `xml:"status"`
+	Xrefs  []xref `xml:"xref"`
+}
+
+type xref struct {
+	Type string `xml:"type,attr"`
+	Data string `xml:"data,attr"`
+}
+
+type person struct {
+	ID   string `xml:"id,attr"`
+	Name string `xml:"name"`
+	Org  string `xml:"org"`
+}
+
+type entry struct {
+	NID        string
+	Kind       string
+	Reference  string
+	Registrant string
+	Status     string
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("gen_namespaces: ")
+	flag.Parse()
+
+	data, err := os.ReadFile(*input)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var reg registry
+
+	if err := xml.Unmarshal(data, &reg); err != nil {
+		log.Fatalf("%s: %v", *input, err)
+	}
+
+	people := map[string]string{}
+
+	for _, p := range reg.People {
+		name := p.Name
+		if name == "" {
+			name = p.Org
+		}
+
+		people[p.ID] = name
+	}
+
+	var entries []entry
+
+	for _, sub := range reg.Registries {
+		var kind string
+
+		switch sub.ID {
+		case formalID:
+			kind = "NIDFormal"
+		case informalID:
+			kind = "NIDInformal"
+		default:
+			continue
+		}
+
+		for _, r := range sub.Records {
+			entries = append(entries, newEntry(r, kind, people))
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].NID < entries[j].NID })
+
+	for i := 1; i < len(entries); i++ {
+		if entries[i].NID == entries[i-1].NID {
+			log.Fatalf("duplicate NID %q", entries[i].NID)
+		}
+	}
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "// Code generated by gen_namespaces.go from %s; DO NOT EDIT.\n\n", *input)
+	fmt.Fprintf(&buf, "package urn\n\n")
+	fmt.Fprintf(&buf, "// ianaUpdated is the date of the registry snapshot, or empty if the\n")
+	fmt.Fprintf(&buf, "// snapshot is an excerpt.\n")
+	fmt.Fprintf(&buf, "const ianaUpdated = %q\n\n", strings.TrimSpace(reg.Updated))
+	fmt.Fprintf(&buf, "var ianaNamespaces = [...]NamespaceInfo{\n")
+
+	for _, e := range entries {
+		fmt.Fprintf(&buf, "\t{NID: %q, Kind: %s, Reference: %q, Registrant: %q, Status: %q},\n",
+			e.NID, e.Kind, e.Reference, e.Registrant, e.Status)
+	}
+
+	fmt.Fprintf(&buf, "}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func newEntry(r record, kind string, people map[string]string) entry {
+	e := entry{
+		NID:    strings.ToLower(strings.TrimSpace(r.Value)),
+		Kind:   kind,
+		Status: strings.ToLower(strings.TrimSpace(r.Status)),
+	}
+
+	if e.Status == "" {
+		e.Status = "active"
+	}
+
+	var refs, registrants []string
+
+	for _, x := range r.Xrefs {
+		switch x.Type {
+		case "rfc":
+			refs = append(refs, "RFC "+strings.TrimPrefix(strings.ToLower(x.Data), "rfc"))
+		case "person":
+			if name, ok := people[x.Data]; ok {
+				registrants = append(registrants, name)
+			} else {
+				registrants = append(registrants, x.Data)
+			}
+		}
+	}
+
+	e.Reference = strings.Join(refs, ", ")
+	e.Registrant = strings.Join(registrants, ", ")
+
+	return e
+}
@@ -0,0 +1,255 @@
+package urn
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ResolverHandler is an HTTP handler resolving URNs with a Resolver.
+// It accepts GET and HEAD requests of three forms:
+//
+//	GET /urn:nbn:de:101:1-201102033592
+//	GET /?urn=urn:nbn:de:101:1-201102033592
+//	GET /uri-res/N2L?urn:nbn:de:101:1-201102033592
+//
+// The first two resolve the N2Ls service.  A single location is
+// answered with a redirection (302 Found), and several with 300
+// Multiple Choices, listing the locations as text/uri-list.  In the
+// first form, the r-component and q-component are taken from the query
+// of the request, as in "/urn:example:a?+r=1".  In the second, the urn
+// parameter is form-encoded, as by url.Values.Encode, or else the URN
+// as is: a value that is a valid URN is kept, with its '+' and
+// percent-encodings, and other values are decoded.
+//
+// The last form is the THTTP protocol of [RFC 2169](urn:ietf:rfc:2169),
+// with the service in the path and the URN as the query.  N2L and I2L
+// redirect to the location, N2R and I2R answer the resource, and the
+// other services answer a text/uri-list.
+//
+// Invalid URNs are answered with 400 Bad Request, describing the error
+// as Error.Pretty does.  URNs without resolver or results are answered
+// with 404 Not Found, and unsupported services with 501 Not
+// Implemented.
+type ResolverHandler struct {
+	Resolver Resolver
+}
+
+// NewResolverHandler returns a handler resolving URNs with r.
+func NewResolverHandler(r Resolver) *ResolverHandler {
+	return &ResolverHandler{Resolver: r}
+}
+
+// thttpPrefix is the path prefix of THTTP requests.
+const thttpPrefix = "/uri-res/"
+
+// ServeHTTP implements http.Handler.
+func (h *ResolverHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	service, raw, thttp := N2Ls, "", false
+	path := r.URL.EscapedPath()
+
+	switch {
+	case strings.HasPrefix(path, thttpPrefix):
+		s, ok := ParseService(strings.TrimPrefix(path, thttpPrefix))
+		if !ok || !handlerServices[s] {
+			http.Error(w, fmt.Sprintf("unsupported service %q", s), http.StatusNotImplemented)
+
+			return
+		}
+
+		service, raw, thttp = s, r.URL.RawQuery, true
+	case len(path) > 1:
+		raw = path[1:]
+
+		if q := r.URL.RawQuery; strings.HasPrefix(q, "+") || strings.HasPrefix(q, "=") {
+			raw += "?" + q
+		}
+	default:
+		raw = queryURN(r.URL.RawQuery)
+	}
+
+	if raw == "" {
+		http.Error(w, "missing URN", http.StatusBadRequest)
+
+		return
+	}
+
+	req := &Request{Service: service, URI: raw}
+
+	u, err := Parse(raw)
+	switch {
+	case err == nil:
+		req = NewRequest(service, u)
+	case service.Input() == 'N':
+		writeError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	resp, err := h.Resolver.Resolve(r.Context(), req)
+	if err != nil {
+		writeResolveError(w, err)
+
+		return
+	}
+
+	if !hasResults(service, resp) {
+		http.Error(w, fmt.Sprintf("%s: %s", raw, ErrNotFound), http.StatusNotFound)
+
+		return
+	}
+
+	if !thttp {
+		writeLocations(w, r, resp)
+
+		return
+	}
+
+	switch service {
+	case N2L, I2L:
+		http.Redirect(w, r, resp.Location().String(), http.StatusFound)
+	case N2R, I2R:
+		res := resp.Resources[0]
+		if res.ContentType != "" {
+			w.Header().Set("Content-Type", res.ContentType)
+		}
+
+		_, _ = w.Write(res.Body)
+	case N2Ls, I2Ls:
+		writeURIList(w, req.URI, http.StatusOK, locationStrings(resp))
+	case N2N, N2Ns, I2N, I2Ns:
+		ids := make([]string, 0, len(resp.URNs))
+		for _, u := range resp.URNs {
+			ids = append(ids, u.String())
+		}
+
+		writeURIList(w, req.URI, http.StatusOK, ids)
+	}
+}
+
+// queryURN returns the value of the first urn parameter of the raw
+// query: the value as is if it is a URN, or else form-decoded.
+func queryURN(query string) string {
+	for _, param := range strings.Split(query, "&") {
+		v, ok := strings.CutPrefix(param, "urn=")
+		if !ok {
+			continue
+		}
+
+		if _, err := Parse(v); err == nil {
+			return v
+		}
+
+		if d, err := url.QueryUnescape(v); err == nil {
+			return d
+		}
+
+		return v
+	}
+
+	return ""
+}
+
+// handlerServices are the THTTP services that the handler can answer.
+var handlerServices = map[Service]bool{
+	N2L: true, N2Ls: true, N2R: true, N2N: true, N2Ns: true,
+	I2L: true, I2Ls: true, I2R: true, I2N: true, I2Ns: true,
+}
+
+// hasResults reports whether the response has the kind of results of
+// the service.
+func hasResults(service Service, resp *Response) bool {
+	if resp == nil {
+		return false
+	}
+
+	switch service[2] {
+	case 'L':
+		return len(resp.Locations) > 0
+	case 'R':
+		return len(resp.Resources) > 0
+	case 'N':
+		return len(resp.URNs) > 0
+	}
+
+	return false
+}
+
+func writeLocations(w http.ResponseWriter, r *http.Request, resp *Response) {
+	locs := locationStrings(resp)
+
+	if len(locs) == 1 {
+		http.Redirect(w, r, locs[0], http.StatusFound)
+
+		return
+	}
+
+	w.Header().Set("Location", locs[0])
+	writeURIList(w, "", http.StatusMultipleChoices, locs)
+}
+
+// writeURIList writes a text/uri-list, from
+// [RFC 2483 §5](urn:ietf:rfc:2483#section-5), with the resolved
+// identifier as a comment.
+func writeURIList(w http.ResponseWriter, comment string, code int, uris []string) {
+	w.Header().Set("Content-Type", "text/uri-list")
+	w.WriteHeader(code)
+
+	if comment != "" {
+		_, _ = io.WriteString(w, "# "+comment+"\r\n")
+	}
+
+	for _, u := range uris {
+		_, _ = io.WriteString(w, u+"\r\n")
+	}
+}
+
+func locationStrings(resp *Response) []string {
+	locs := make([]string, 0, len(resp.Locations))
+
+	for _, u := range resp.Locations {
+		locs = append(locs, u.String())
+	}
+
+	return locs
+}
+
+// writeError writes the error with the code, described as by
+// Error.Pretty if it is an *Error.
+func writeError(w http.ResponseWriter, err error, code int) {
+	var uerr *Error
+
+	if errors.As(err, &uerr) {
+		http.Error(w, uerr.Pretty(), code)
+
+		return
+	}
+
+	http.Error(w, err.Error(), code)
+}
+
+func writeResolveError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrNoResolver), errors.Is(err, ErrNotFound):
+		writeError(w, err, http.StatusNotFound)
+	case errors.Is(err, ErrUnsupportedService):
+		writeError(w, err, http.StatusNotImplemented)
+	case errors.Is(err, ErrInvalidIdentifier), errors.Is(err, ErrInvalidScheme),
+		errors.Is(err, ErrInvalidNID), errors.Is(err, ErrInvalidNSS),
+		errors.Is(err, ErrInvalidResolve), errors.Is(err, ErrInvalidQuery),
+		errors.Is(err, ErrInvalidEncoding):
+		writeError(w, err, http.StatusBadRequest)
+	default:
+		http.Error(w, "resolution failed", http.StatusBadGateway)
+	}
+}
@@ -0,0 +1,212 @@
+package urn_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/paulourio/go-urn"
+	"github.com/stretchr/testify/assert"
+)
+
+// testHandlerResolver resolves the "nbn" NID to locations, resources
+// and URNs, and fails for the "fail" NID.
+func testHandlerResolver() urn.Resolver {
+	m := urn.NewResolverMux()
+
+	_ = m.Handle("nbn", urn.ResolverFunc(func(_ context.Context, req *urn.Request) (*urn.Response, error) {
+		switch req.Service {
+		case urn.N2Ls, urn.I2Ls:
+			resp := &urn.Response{}
+
+			for _, s := range []string{"https://a.example/", "https://b.example/"} {
+				u, _ := url.Parse(s + req.URN.NSS)
+				resp.Locations = append(resp.Locations, u)
+			}
+
+			if req.URN.NSS == "single" || req.Hints.Has("mirror") {
+				resp.Locations = resp.Locations[:1]
+			}
+
+			return resp, nil
+		case urn.N2R:
+			return &urn.Response{Resources: []urn.Resource{
+				{ContentType: "text/plain", Body: []byte("resource " + req.URN.NSS)},
+			}}, nil
+		case urn.N2Ns:
+			u, _ := urn.Parse("urn:nbn:alias-" + req.URN.NSS)
+
+			return &urn.Response{URNs: []*urn.URN{u}}, nil
+		}
+
+		return nil, urn.ErrUnsupportedService
+	}))
+	_ = m.Handle("fail", urn.ResolverFunc(func(context.Context, *urn.Request) (*urn.Response, error) {
+		return nil, errors.New("backend down")
+	}))
+
+	return m
+}
+
+func TestResolverHandler(t *testing.T) {
+	t.Parallel()
+
+	h := urn.NewResolverHandler(testHandlerResolver())
+
+	cases := []struct {
+		Method      string
+		Target      string
+		Code        int
+		Location    string
+		ContentType string
+		Body        string
+	}{
+		{
+			Target:   "/urn:nbn:single",
+			Code:     http.StatusFound,
+			Location: "https://a.example/single",
+		},
+		{
+			Target:      "/urn:nbn:de:1",
+			Code:        http.StatusMultipleChoices,
+			Location:    "https://a.example/de:1",
+			ContentType: "text/uri-list",
+			Body:        "https://a.example/de:1\r\nhttps://b.example/de:1\r\n",
+		},
+		{
+			Target:   "/urn:nbn:de:1?+mirror=a?=q=1",
+			Code:     http.StatusFound,
+			Location: "https://a.example/de:1",
+		},
+		{
+			Target:   "/?urn=" + url.QueryEscape("URN:NBN:single"),
+			Code:     http.StatusFound,
+			Location: "https://a.example/single",
+		},
+		{
+			Target:   "/?" + url.Values{"urn": {"urn:nbn:a/b%20c+d"}}.Encode(),
+			Code:     http.StatusMultipleChoices,
+			Location: "https://a.example/a/b%20c+d",
+		},
+		{
+			Target:   "/?urn=URN:NBN:single",
+			Code:     http.StatusFound,
+			Location: "https://a.example/single",
+		},
+		{
+			// '+' and percent-encodings are part of the URN.
+			Target:   "/?x=1&urn=urn:nbn:a+b%2Fc&urn=urn:nbn:single",
+			Code:     http.StatusMultipleChoices,
+			Location: "https://a.example/a+b%2Fc",
+		},
+		{
+			Target:   "/?urn=" + url.QueryEscape("urn:nbn:de:1?+mirror=a&b?=q#frag"),
+			Code:     http.StatusFound,
+			Location: "https://a.example/de:1",
+		},
+		{
+			Target:   "/uri-res/N2L?urn:nbn:de:1",
+			Code:     http.StatusFound,
+			Location: "https://a.example/de:1",
+		},
+		{
+			Target:      "/uri-res/N2Ls?urn:nbn:de:1",
+			Code:        http.StatusOK,
+			ContentType: "text/uri-list",
+			Body:        "# urn:nbn:de:1\r\nhttps://a.example/de:1\r\nhttps://b.example/de:1\r\n",
+		},
+		{
+			Target:      "/uri-res/I2Ls?urn:nbn:de:1",
+			Code:        http.StatusOK,
+			ContentType: "text/uri-list",
+			Body:        "# urn:nbn:de:1\r\nhttps://a.example/de:1\r\nhttps://b.example/de:1\r\n",
+		},
+		{
+			Target:      "/uri-res/N2R?urn:nbn:x",
+			Code:        http.StatusOK,
+			ContentType: "text/plain",
+			Body:        "resource x",
+		},
+		{
+			Target:      "/uri-res/N2N?urn:nbn:x",
+			Code:        http.StatusOK,
+			ContentType: "text/uri-list",
+			Body:        "# urn:nbn:x\r\nurn:nbn:alias-x\r\n",
+		},
+		{
+			Method:   http.MethodHead,
+			Target:   "/urn:nbn:single",
+			Code:     http.StatusFound,
+			Location: "https://a.example/single",
+		},
+		// Errors.
+		{
+			Target: "/urn:a:b",
+			Code:   http.StatusBadRequest,
+			Body:   "parse \"urn:a:b\": invalid NID: too short\n  urn:a:b\n      ^\n",
+		},
+		{Target: "/", Code: http.StatusBadRequest, Body: "missing URN\n"},
+		{Target: "/?urn", Code: http.StatusBadRequest, Body: "missing URN\n"},
+		{Target: "/?urn=urn:nbn:a%2", Code: http.StatusBadRequest},
+		{Target: "/uri-res/N2L", Code: http.StatusBadRequest, Body: "missing URN\n"},
+		{Target: "/uri-res/N2L?urn:nbn", Code: http.StatusBadRequest},
+		{Target: "/uri-res/X2Y?urn:nbn:x", Code: http.StatusNotImplemented},
+		{Target: "/uri-res/N2C?urn:nbn:x", Code: http.StatusNotImplemented},
+		{Target: "/uri-res/I2L?https://example.com/", Code: http.StatusNotFound},
+		{Target: "/uri-res/N2Ns?urn:other:x", Code: http.StatusNotFound},
+		{Target: "/urn:other:x", Code: http.StatusNotFound},
+		{Target: "/urn:fail:x", Code: http.StatusBadGateway, Body: "resolution failed\n"},
+		{Method: http.MethodPost, Target: "/urn:nbn:x", Code: http.StatusMethodNotAllowed},
+	}
+
+	for i, c := range cases {
+		method := c.Method
+		if method == "" {
+			method = http.MethodGet
+		}
+
+		msg := fmt.Sprintf("case %d: %s %s", i+1, method, c.Target)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(method, c.Target, nil))
+
+		res := rec.Result()
+		body, _ := io.ReadAll(res.Body)
+
+		assert.Equal(t, c.Code, res.StatusCode, "%s: %s", msg, body)
+		assert.Equal(t, c.Location, res.Header.Get("Location"), msg)
+
+		if c.ContentType != "" {
+			assert.Equal(t, c.ContentType, res.Header.Get("Content-Type"), msg)
+		}
+
+		if c.Body != "" {
+			assert.Equal(t, c.Body, string(body), msg)
+		}
+	}
+}
+
+func TestResolverHandlerServer(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(urn.NewResolverHandler(testHandlerResolver()))
+	defer srv.Close()
+
+	client := srv.Client()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	res, err := client.Get(srv.URL + "/urn:nbn:de:101:1-201102033592")
+	if assert.NoError(t, err) {
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusMultipleChoices, res.StatusCode)
+		assert.Equal(t, "https://a.example/de:101:1-201102033592", res.Header.Get("Location"))
+	}
+}
@@ -0,0 +1,48 @@
+package urn
+
+import (
+	"sort"
+	"strings"
+)
+
+//go:generate go run gen_namespaces.go -input testdata/urn-namespaces.xml -output namespaces_iana.go
+
+// NamespaceInfo describes a namespace registered with IANA, in the
+// "Formal URN Namespaces" or "Informal URN Namespaces" registries.
+type NamespaceInfo struct {
+	NID        string  // lowercase Namespace Identifier
+	Kind       NIDKind // NIDFormal or NIDInformal, by registry
+	Reference  string  // defining RFCs, such as "RFC 2648"
+	Registrant string  // registrant named in the registry, if any
+	Status     string  // "active", unless the registry states otherwise
+}
+
+// LookupNamespace returns the IANA registration of a NID, from the
+// snapshot of the registry embedded in this package.  The lookup is
+// case-insensitive.  It reports false if the NID is not registered,
+// which can be used to warn about URNs in unregistered namespaces.
+//
+// The snapshot is not updated at run time, so recent registrations may
+// be missing.  If the snapshot is an excerpt of the registry, as
+// reported by IANASnapshot, false does not mean that the NID is not
+// registered.
+func LookupNamespace(nid string) (NamespaceInfo, bool) {
+	nid = strings.ToLower(nid)
+
+	i := sort.Search(len(ianaNamespaces), func(i int) bool {
+		return ianaNamespaces[i].NID >= nid
+	})
+
+	if i < len(ianaNamespaces) && ianaNamespaces[i].NID == nid {
+		return ianaNamespaces[i], true
+	}
+
+	return NamespaceInfo{}, false
+}
+
+// IANASnapshot returns the date of the snapshot of the registry used by
+// LookupNamespace, and whether the snapshot is the complete registry,
+// which an excerpt without the date of the registry is not.
+func IANASnapshot() (updated string, complete bool) {
+	return ianaUpdated, ianaUpdated != ""
+}
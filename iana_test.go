@@ -0,0 +1,109 @@
+package urn_test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/paulourio/go-urn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupNamespace(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		NID  string
+		Info urn.NamespaceInfo
+		OK   bool
+	}{
+		{
+			NID: "ietf",
+			Info: urn.NamespaceInfo{
+				NID: "ietf", Kind: urn.NIDFormal, Reference: "RFC 2648", Status: "active",
+			},
+			OK: true,
+		},
+		{
+			NID: "ISBN",
+			Info: urn.NamespaceInfo{
+				NID: "isbn", Kind: urn.NIDFormal, Reference: "RFC 8254",
+				Registrant: "International ISBN Agency", Status: "active",
+			},
+			OK: true,
+		},
+		{NID: "3gpp", Info: urn.NamespaceInfo{NID: "3gpp", Kind: urn.NIDFormal, Reference: "RFC 5279", Status: "active"}, OK: true},
+		{NID: "xmlorg", Info: urn.NamespaceInfo{NID: "xmlorg", Kind: urn.NIDFormal, Reference: "RFC 3120", Status: "active"}, OK: true},
+		{NID: "unregistered"},
+		{NID: ""},
+		{NID: "zzz"},
+	}
+
+	for i, c := range cases {
+		msg := fmt.Sprintf("case %d: %q", i+1, c.NID)
+
+		info, ok := urn.LookupNamespace(c.NID)
+		assert.Equal(t, c.OK, ok, msg)
+		assert.Equal(t, c.Info, info, msg)
+	}
+}
+
+// TestLookupNamespaceRegistry checks that the snapshot is the complete
+// registry, with formal and informal NIDs.
+func TestLookupNamespaceRegistry(t *testing.T) {
+	t.Parallel()
+
+	updated, complete := urn.IANASnapshot()
+	assert.True(t, complete)
+	assert.Regexp(t, `^\d{4}-\d{2}-\d{2}$`, updated)
+
+	for _, nid := range []string{"uci", "lex"} {
+		info, ok := urn.LookupNamespace(nid)
+		if assert.True(t, ok, nid) {
+			assert.Equal(t, urn.NIDFormal, info.Kind, nid)
+		}
+	}
+
+	info, ok := urn.LookupNamespace("URN-1")
+	if assert.True(t, ok) {
+		assert.Equal(t, "urn-1", info.NID)
+		assert.Equal(t, urn.NIDInformal, info.Kind)
+	}
+}
+
+// TestLookupNamespaceSnapshot checks that the generated table is in sync
+// with the registry snapshot.  Run go generate if it fails.
+func TestLookupNamespaceSnapshot(t *testing.T) {
+	t.Parallel()
+
+	data, err := os.ReadFile("testdata/urn-namespaces.xml")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var reg struct {
+		Registries []struct {
+			ID     string   `xml:"id,attr"`
+			Values []string `xml:"record>value"`
+		} `xml:"registry"`
+	}
+
+	if !assert.NoError(t, xml.Unmarshal(data, &reg)) {
+		return
+	}
+
+	kinds := map[string]urn.NIDKind{
+		"urn-namespaces-1": urn.NIDFormal,
+		"urn-namespaces-2": urn.NIDInformal,
+	}
+
+	for _, sub := range reg.Registries {
+		for _, nid := range sub.Values {
+			info, ok := urn.LookupNamespace(nid)
+			if assert.True(t, ok, "NID %q is missing, run go generate", nid) {
+				assert.Equal(t, kinds[sub.ID], info.Kind, nid)
+			}
+		}
+	}
+}
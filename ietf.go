@@ -0,0 +1,245 @@
+package urn
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// IETFNamespace implements the "ietf" namespace from
+// [RFC 2648](urn:ietf:rfc:2648), extended with the "params"
+// sub-namespace from [RFC 3553](urn:ietf:rfc:3553).  The NSS is
+// case-insensitive and normalized to lowercase.  Document and meeting
+// numbers are decimal without leading zeros, so "urn:ietf:rfc:08141"
+// is invalid rather than an alias of "urn:ietf:rfc:8141".
+var IETFNamespace Namespace = ietfNamespace{}
+
+type ietfNamespace struct{}
+
+func (ietfNamespace) NID() string { return "ietf" }
+
+func (ietfNamespace) Validate(nss string) error {
+	_, err := decodeIETF(nss)
+
+	return err
+}
+
+func (ietfNamespace) Normalize(nss string) string {
+	return normalizePercentEncoding(strings.ToLower(nss))
+}
+
+// IETFSeries is a sub-namespace of the "ietf" namespace.
+type IETFSeries string
+
+const (
+	IETFRFC     IETFSeries = "rfc"    // Request for Comments
+	IETFFYI     IETFSeries = "fyi"    // For Your Information
+	IETFSTD     IETFSeries = "std"    // Standard
+	IETFBCP     IETFSeries = "bcp"    // Best Current Practice
+	IETFDraft   IETFSeries = "id"     // Internet-Draft
+	IETFMeeting IETFSeries = "mtg"    // IETF meeting and its working group
+	IETFParams  IETFSeries = "params" // IETF registered protocol parameter
+)
+
+// IETFReference is a decoded reference of the "ietf" namespace.
+type IETFReference struct {
+	Series IETFSeries
+
+	// Number is the document number of the "rfc", "fyi", "std" and
+	// "bcp" series, or the meeting number of the "mtg" series.
+	Number int
+
+	// Name is the draft name, without its "draft-" prefix, of the "id"
+	// series; the working group of the "mtg" series; or the parameter
+	// name of the "params" series.
+	Name string
+}
+
+// ParseIETF decodes an "ietf" URN into a typed reference.
+func ParseIETF(u *URN) (*IETFReference, error) {
+	if !strings.EqualFold(u.NID, "ietf") {
+		return nil, &Error{
+			Op:   "ietf",
+			Data: u.String(),
+			Err:  ErrInvalidNID,
+			Msg:  "not an ietf namespace",
+		}
+	}
+
+	ref, err := decodeIETF(u.NSS)
+	if err != nil {
+		return nil, &Error{Op: "ietf", Data: u.String(), Err: ErrInvalidNSS, Msg: err.Error()}
+	}
+
+	return ref, nil
+}
+
+// URN returns the normalized "ietf" URN of the reference.
+func (r *IETFReference) URN() *URN {
+	var nss string
+
+	switch r.Series {
+	case IETFDraft, IETFParams:
+		nss = string(r.Series) + ":" + r.Name
+	case IETFMeeting:
+		nss = "mtg:" + strconv.Itoa(r.Number)
+		if r.Name != "" {
+			nss += "-" + r.Name
+		}
+	default:
+		nss = string(r.Series) + ":" + strconv.Itoa(r.Number)
+	}
+
+	return &URN{
+		Scheme:     "urn",
+		NID:        "ietf",
+		NSS:        IETFNamespace.Normalize(nss),
+		normalized: true,
+	}
+}
+
+// IETFLinks renders web locations of "ietf" URNs.  Empty fields use the
+// public sites of the IETF, RFC Editor and IANA.
+type IETFLinks struct {
+	RFCEditor   string // base URL for the document series
+	Datatracker string // base URL for drafts and meetings
+	IANA        string // base URL for protocol parameters
+}
+
+// DefaultIETFLinks renders locations on the public sites.
+var DefaultIETFLinks = &IETFLinks{}
+
+// URL returns the web location of the document referenced by an "ietf"
+// URN.  The f-component, if any, is kept as the URL fragment, so that
+// "urn:ietf:rfc:8141#section-2" links to that section of RFC 8141.
+func (l *IETFLinks) URL(u *URN) (string, error) {
+	ref, err := ParseIETF(u)
+	if err != nil {
+		return "", err
+	}
+
+	var s string
+
+	switch ref.Series {
+	case IETFRFC:
+		s = fmt.Sprintf("%s/rfc/rfc%d", l.rfcEditor(), ref.Number)
+	case IETFFYI, IETFSTD, IETFBCP:
+		s = fmt.Sprintf("%s/info/%s%d", l.rfcEditor(), ref.Series, ref.Number)
+	case IETFDraft:
+		s = fmt.Sprintf("%s/doc/draft-%s", l.datatracker(), ref.Name)
+	case IETFMeeting:
+		s = fmt.Sprintf("%s/meeting/%d", l.datatracker(), ref.Number)
+		if ref.Name != "" {
+			s += "/session/" + ref.Name
+		}
+	case IETFParams:
+		s = l.iana() + "/assignments/params/params.xhtml"
+	}
+
+	if u.Fragment != "" {
+		s += "#" + u.Fragment
+	}
+
+	return s, nil
+}
+
+func (l *IETFLinks) rfcEditor() string {
+	return baseURL(l.RFCEditor, "https://www.rfc-editor.org")
+}
+
+func (l *IETFLinks) datatracker() string {
+	return baseURL(l.Datatracker, "https://datatracker.ietf.org")
+}
+
+func (l *IETFLinks) iana() string {
+	return baseURL(l.IANA, "https://www.iana.org")
+}
+
+func baseURL(s, def string) string {
+	if s == "" {
+		return def
+	}
+
+	return strings.TrimSuffix(s, "/")
+}
+
+func decodeIETF(nss string) (*IETFReference, error) {
+	i := strings.IndexByte(nss, ':')
+	if i < 0 {
+		return nil, errors.New("missing series")
+	}
+
+	ref := &IETFReference{Series: IETFSeries(strings.ToLower(nss[:i]))}
+	rest := nss[i+1:]
+
+	if rest == "" {
+		return nil, fmt.Errorf("empty %s reference", ref.Series)
+	}
+
+	switch ref.Series {
+	case IETFRFC, IETFFYI, IETFSTD, IETFBCP:
+		n, err := decodeIETFNumber(rest)
+		if err != nil {
+			return nil, err
+		}
+
+		ref.Number = n
+	case IETFDraft:
+		if strings.ContainsAny(rest, ":/") {
+			return nil, errors.New("invalid draft name")
+		}
+
+		ref.Name = strings.ToLower(rest)
+	case IETFMeeting:
+		num, group := rest, ""
+		if j := strings.IndexByte(rest, '-'); j >= 0 {
+			num, group = rest[:j], rest[j+1:]
+
+			if group == "" || strings.ContainsAny(group, ":/") {
+				return nil, errors.New("invalid working group")
+			}
+		}
+
+		n, err := decodeIETFNumber(num)
+		if err != nil {
+			return nil, err
+		}
+
+		ref.Number = n
+		ref.Name = strings.ToLower(group)
+	case IETFParams:
+		if strings.HasPrefix(rest, ":") {
+			return nil, errors.New("invalid parameter name")
+		}
+
+		ref.Name = strings.ToLower(rest)
+	default:
+		return nil, fmt.Errorf("unknown series %q", ref.Series)
+	}
+
+	return ref, nil
+}
+
+func decodeIETFNumber(s string) (int, error) {
+	if s == "" {
+		return 0, errors.New("missing number")
+	}
+
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return 0, errors.New("invalid number")
+		}
+	}
+
+	if len(s) > 1 && s[0] == '0' {
+		return 0, errors.New("number has leading zeros")
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errors.New("number out of range")
+	}
+
+	return n, nil
+}
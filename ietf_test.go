@@ -0,0 +1,146 @@
+package urn_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/paulourio/go-urn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIETF(t *testing.T) {
+	t.Parallel()
+
+	for i, c := range ietfCases {
+		msg := fmt.Sprintf("case %d: %q", i+1, c.Input)
+
+		u, err := urn.Parse(c.Input)
+		if !assert.NoError(t, err, msg) {
+			continue
+		}
+
+		ref, err := urn.ParseIETF(u)
+		if c.Ref == nil {
+			assert.ErrorIs(t, err, urn.ErrInvalidNSS, msg)
+			assert.ErrorIs(t, urn.DefaultRegistry.Validate(u), urn.ErrInvalidNSS, msg)
+
+			continue
+		}
+
+		if assert.NoError(t, err, msg) {
+			assert.Equal(t, c.Ref, ref, msg)
+			assert.True(t, urn.Equal(u, ref.URN(), urn.AssignedName, urn.NamespaceNormalized), msg)
+
+			link, err := urn.DefaultIETFLinks.URL(u)
+			if assert.NoError(t, err, msg) {
+				assert.Equal(t, c.URL, link, msg)
+			}
+		}
+	}
+
+	u, _ := urn.Parse("urn:isbn:0451450523")
+	_, err := urn.ParseIETF(u)
+	assert.ErrorIs(t, err, urn.ErrInvalidNID)
+}
+
+func TestIETFLinks(t *testing.T) {
+	t.Parallel()
+
+	links := &urn.IETFLinks{
+		RFCEditor:   "https://mirror.example.com/",
+		Datatracker: "https://tracker.example.com",
+	}
+
+	cases := map[string]string{
+		"urn:ietf:rfc:8141#section-3.1": "https://mirror.example.com/rfc/rfc8141#section-3.1",
+		"urn:ietf:bcp:14":               "https://mirror.example.com/info/bcp14",
+		"urn:ietf:id:ietf-urnbis-rfc2141bis-urn": "https://tracker.example.com/doc/" +
+			"draft-ietf-urnbis-rfc2141bis-urn",
+	}
+
+	for in, out := range cases {
+		u, _ := urn.Parse(in)
+
+		link, err := links.URL(u)
+		if assert.NoError(t, err, in) {
+			assert.Equal(t, out, link, in)
+		}
+	}
+}
+
+func TestIETFEquivalence(t *testing.T) {
+	t.Parallel()
+
+	a, _ := urn.Parse("URN:IETF:RFC:8141")
+	b, _ := urn.Parse("urn:ietf:rfc:8141")
+
+	assert.True(t, urn.Equal(a, b, urn.AssignedName, urn.NamespaceNormalized))
+	assert.False(t, urn.Equal(a, b, urn.AssignedName, urn.CaseNormalized))
+}
+
+type ietfTestCase struct {
+	Input string
+	Ref   *urn.IETFReference // nil if invalid
+	URL   string
+}
+
+var ietfCases = []*ietfTestCase{
+	{
+		Input: "urn:ietf:rfc:2648",
+		Ref:   &urn.IETFReference{Series: urn.IETFRFC, Number: 2648},
+		URL:   "https://www.rfc-editor.org/rfc/rfc2648",
+	},
+	{
+		Input: "urn:IETF:RFC:8141#section-2",
+		Ref:   &urn.IETFReference{Series: urn.IETFRFC, Number: 8141},
+		URL:   "https://www.rfc-editor.org/rfc/rfc8141#section-2",
+	},
+	{
+		Input: "urn:ietf:std:66",
+		Ref:   &urn.IETFReference{Series: urn.IETFSTD, Number: 66},
+		URL:   "https://www.rfc-editor.org/info/std66",
+	},
+	{
+		Input: "urn:ietf:bcp:14",
+		Ref:   &urn.IETFReference{Series: urn.IETFBCP, Number: 14},
+		URL:   "https://www.rfc-editor.org/info/bcp14",
+	},
+	{
+		Input: "urn:ietf:fyi:36",
+		Ref:   &urn.IETFReference{Series: urn.IETFFYI, Number: 36},
+		URL:   "https://www.rfc-editor.org/info/fyi36",
+	},
+	{
+		Input: "urn:ietf:id:ietf-urn-ietf",
+		Ref:   &urn.IETFReference{Series: urn.IETFDraft, Name: "ietf-urn-ietf"},
+		URL:   "https://datatracker.ietf.org/doc/draft-ietf-urn-ietf",
+	},
+	{
+		Input: "urn:ietf:mtg:41-urn",
+		Ref:   &urn.IETFReference{Series: urn.IETFMeeting, Number: 41, Name: "urn"},
+		URL:   "https://datatracker.ietf.org/meeting/41/session/urn",
+	},
+	{
+		Input: "urn:ietf:mtg:41",
+		Ref:   &urn.IETFReference{Series: urn.IETFMeeting, Number: 41},
+		URL:   "https://datatracker.ietf.org/meeting/41",
+	},
+	{
+		Input: "urn:ietf:params:xml:ns:netconf:base:1.0",
+		Ref:   &urn.IETFReference{Series: urn.IETFParams, Name: "xml:ns:netconf:base:1.0"},
+		URL:   "https://www.iana.org/assignments/params/params.xhtml",
+	},
+	{Input: "urn:ietf:rfc"},
+	{Input: "urn:ietf:rfc:"},
+	{Input: "urn:ietf:rfc:abc"},
+	{Input: "urn:ietf:rfc:99999999999999999999999"},
+	{Input: "urn:ietf:rfc:08141"},
+	{Input: "urn:ietf:std:00"},
+	{Input: "urn:ietf:mtg:041-urn"},
+	{Input: "urn:ietf:bcp:14:1"},
+	{Input: "urn:ietf:mtg:-urn"},
+	{Input: "urn:ietf:mtg:41-"},
+	{Input: "urn:ietf:id:ietf:urn"},
+	{Input: "urn:ietf:params::x"},
+	{Input: "urn:ietf:unknown:1"},
+}
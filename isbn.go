@@ -0,0 +1,219 @@
+package urn
+
+import (
+	"errors"
+	"strings"
+)
+
+// ISBNNamespace implements the "isbn" namespace from
+// [RFC 3187](urn:ietf:rfc:3187), transitioned by
+// [RFC 8254](urn:ietf:rfc:8254).  The NSS is an ISBN-10 or ISBN-13,
+// optionally hyphenated, with a valid check digit.
+//
+// Hyphens are not significant and a trailing "x" check digit is
+// case-insensitive.  The canonical form of an ISBN is its unhyphenated
+// ISBN-13, so that both ISBNs assigned to a book are equivalent.
+var ISBNNamespace Namespace = isbnNamespace{}
+
+type isbnNamespace struct{}
+
+func (isbnNamespace) NID() string { return "isbn" }
+
+func (isbnNamespace) Validate(nss string) error {
+	_, err := compactISBN(nss)
+
+	return err
+}
+
+func (isbnNamespace) Normalize(nss string) string {
+	isbn, err := ISBN10To13(nss)
+	if err != nil {
+		isbn, _ = compactISBN(nss)
+	}
+
+	return isbn
+}
+
+// ISSNNamespace implements the "issn" namespace from
+// [RFC 3044](urn:ietf:rfc:3044), transitioned by
+// [RFC 8254](urn:ietf:rfc:8254).  The NSS is an ISSN with a valid check
+// digit, whose canonical form is "NNNN-NNNC" with an uppercase "X".
+var ISSNNamespace Namespace = issnNamespace{}
+
+type issnNamespace struct{}
+
+func (issnNamespace) NID() string { return "issn" }
+
+func (issnNamespace) Validate(nss string) error {
+	_, err := compactISSN(nss)
+
+	return err
+}
+
+func (issnNamespace) Normalize(nss string) string {
+	issn, err := compactISSN(nss)
+	if err != nil {
+		return nss
+	}
+
+	return issn[:4] + "-" + issn[4:]
+}
+
+// ISBN10To13 converts an ISBN to its unhyphenated ISBN-13 form.  An
+// ISBN-13 is returned without hyphens.
+func ISBN10To13(isbn string) (string, error) {
+	s, err := compactISBN(isbn)
+	if err != nil {
+		return "", &Error{Op: "isbn", Data: isbn, Err: ErrInvalidNSS, Msg: err.Error()}
+	}
+
+	if len(s) == 13 {
+		return s, nil
+	}
+
+	s = "978" + s[:9]
+
+	return s + string(isbn13CheckDigit(s)), nil
+}
+
+// ISBN13To10 converts an ISBN to its unhyphenated ISBN-10 form.  Only
+// ISBN-13s with the "978" prefix have an ISBN-10 equivalent.  An ISBN-10
+// is returned without hyphens.
+func ISBN13To10(isbn string) (string, error) {
+	s, err := compactISBN(isbn)
+	if err != nil {
+		return "", &Error{Op: "isbn", Data: isbn, Err: ErrInvalidNSS, Msg: err.Error()}
+	}
+
+	if len(s) == 10 {
+		return s, nil
+	}
+
+	if !strings.HasPrefix(s, "978") {
+		return "", &Error{
+			Op:   "isbn",
+			Data: isbn,
+			Err:  ErrInvalidNSS,
+			Msg:  "no ISBN-10 equivalent",
+		}
+	}
+
+	s = s[3:12]
+
+	return s + string(isbn10CheckDigit(s)), nil
+}
+
+// compactISBN returns the ISBN without hyphens and with an uppercase
+// check digit, or an error if it is not a valid ISBN.
+func compactISBN(isbn string) (string, error) {
+	s, err := compactDigits(isbn)
+	if err != nil {
+		return "", err
+	}
+
+	switch len(s) {
+	case 10:
+		if isbn10CheckDigit(s[:9]) != s[9] {
+			return "", errors.New("invalid check digit")
+		}
+	case 13:
+		if s[12] == 'X' {
+			return "", errors.New("invalid byte")
+		}
+
+		if !strings.HasPrefix(s, "978") && !strings.HasPrefix(s, "979") {
+			return "", errors.New("invalid prefix")
+		}
+
+		if isbn13CheckDigit(s[:12]) != s[12] {
+			return "", errors.New("invalid check digit")
+		}
+	default:
+		return "", errors.New("invalid length")
+	}
+
+	return s, nil
+}
+
+// compactISSN returns the ISSN without hyphen and with an uppercase
+// check digit, or an error if it is not a valid ISSN.
+func compactISSN(issn string) (string, error) {
+	if strings.Count(issn, "-") > 1 || (len(issn) == 9 && issn[4] != '-') {
+		return "", errors.New("invalid hyphenation")
+	}
+
+	s, err := compactDigits(issn)
+	if err != nil {
+		return "", err
+	}
+
+	if len(s) != 8 {
+		return "", errors.New("invalid length")
+	}
+
+	sum := 0
+	for i := 0; i < 7; i++ {
+		sum += int(s[i]-'0') * (8 - i)
+	}
+
+	if checkDigit((11-sum%11)%11) != s[7] {
+		return "", errors.New("invalid check digit")
+	}
+
+	return s, nil
+}
+
+// compactDigits removes single hyphens between digits and uppercases
+// a final "x".  It fails on any other byte.
+func compactDigits(s string) (string, error) {
+	b := make([]byte, 0, len(s))
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case isDigit(c):
+			b = append(b, c)
+		case c == '-':
+			if i == 0 || i == len(s)-1 || s[i-1] == '-' {
+				return "", errors.New("invalid hyphenation")
+			}
+		case (c == 'X' || c == 'x') && i == len(s)-1:
+			b = append(b, 'X')
+		default:
+			return "", errors.New("invalid byte")
+		}
+	}
+
+	return string(b), nil
+}
+
+func isbn10CheckDigit(s string) byte {
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += int(s[i]-'0') * (10 - i)
+	}
+
+	return checkDigit((11 - sum%11) % 11)
+}
+
+func isbn13CheckDigit(s string) byte {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		if i%2 == 0 {
+			sum += int(s[i] - '0')
+		} else {
+			sum += 3 * int(s[i]-'0')
+		}
+	}
+
+	return checkDigit((10 - sum%10) % 10)
+}
+
+func checkDigit(n int) byte {
+	if n == 10 {
+		return 'X'
+	}
+
+	return byte('0' + n)
+}
@@ -0,0 +1,122 @@
+package urn_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/paulourio/go-urn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestISBN(t *testing.T) {
+	t.Parallel()
+
+	for i, c := range isbnCases {
+		msg := fmt.Sprintf("case %d: %q", i+1, c.Input)
+
+		isbn13, err := urn.ISBN10To13(c.Input)
+		if c.ISBN13 == "" {
+			assert.ErrorIs(t, err, urn.ErrInvalidNSS, msg)
+			assert.Error(t, urn.ISBNNamespace.Validate(c.Input), msg)
+
+			continue
+		}
+
+		if assert.NoError(t, err, msg) {
+			assert.Equal(t, c.ISBN13, isbn13, msg)
+			assert.NoError(t, urn.ISBNNamespace.Validate(c.Input), msg)
+			assert.Equal(t, c.ISBN13, urn.ISBNNamespace.Normalize(c.Input), msg)
+		}
+
+		isbn10, err := urn.ISBN13To10(c.Input)
+		if c.ISBN10 == "" {
+			assert.ErrorIs(t, err, urn.ErrInvalidNSS, msg)
+		} else if assert.NoError(t, err, msg) {
+			assert.Equal(t, c.ISBN10, isbn10, msg)
+		}
+	}
+}
+
+func TestISBNEquivalence(t *testing.T) {
+	t.Parallel()
+
+	a, _ := urn.Parse("urn:isbn:0-451-45052-3")
+	b, _ := urn.Parse("urn:ISBN:9780451450524")
+	c, _ := urn.Parse("urn:isbn:0451450531")
+
+	assert.True(t, urn.Equal(a, b, urn.AssignedName, urn.NamespaceNormalized))
+	assert.False(t, urn.Equal(a, c, urn.AssignedName, urn.NamespaceNormalized))
+
+	_, err := urn.DefaultRegistry.Parse("urn:isbn:banana")
+	assert.ErrorIs(t, err, urn.ErrInvalidNSS)
+}
+
+func TestISSN(t *testing.T) {
+	t.Parallel()
+
+	for i, c := range issnCases {
+		msg := fmt.Sprintf("case %d: %q", i+1, c.Input)
+
+		err := urn.ISSNNamespace.Validate(c.Input)
+		if c.Norm == "" {
+			assert.Error(t, err, msg)
+
+			continue
+		}
+
+		if assert.NoError(t, err, msg) {
+			assert.Equal(t, c.Norm, urn.ISSNNamespace.Normalize(c.Input), msg)
+		}
+	}
+
+	a, _ := urn.Parse("urn:ISSN:0167-6423")
+	b, _ := urn.Parse("urn:issn:01676423")
+
+	assert.True(t, urn.Equal(a, b, urn.AssignedName, urn.NamespaceNormalized))
+}
+
+type isbnTestCase struct {
+	Input  string
+	ISBN13 string // empty if invalid
+	ISBN10 string // empty if no ISBN-10 exists
+}
+
+var isbnCases = []*isbnTestCase{
+	{"0451450523", "9780451450524", "0451450523"},
+	{"0-451-45052-3", "9780451450524", "0451450523"},
+	{"978-0-451-45052-4", "9780451450524", "0451450523"},
+	{"9780451450524", "9780451450524", "0451450523"},
+	{"0-8044-2957-X", "9780804429573", "080442957X"},
+	{"080442957x", "9780804429573", "080442957X"},
+	{"979-10-90636-07-1", "9791090636071", ""},
+	{"", "", ""},
+	{"banana", "", ""},
+	{"0451450524", "", ""},
+	{"9780451450525", "", ""},
+	{"9770451450520", "", ""},
+	{"978045145052X", "", ""},
+	{"04514505X3", "", ""},
+	{"-0451450523", "", ""},
+	{"0451450523-", "", ""},
+	{"0--451450523", "", ""},
+	{"045145052", "", ""},
+}
+
+type issnTestCase struct {
+	Input string
+	Norm  string // empty if invalid
+}
+
+var issnCases = []*issnTestCase{
+	{"0167-6423", "0167-6423"},
+	{"01676423", "0167-6423"},
+	{"0317-8471", "0317-8471"},
+	{"2434-561x", "2434-561X"},
+	{"2434-561X", "2434-561X"},
+	{"0167-6424", ""},
+	{"016-76423", ""},
+	{"0167--6423", ""},
+	{"0167-642", ""},
+	{"0167-64233", ""},
+	{"", ""},
+}
@@ -0,0 +1,197 @@
+package urn
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// A Map maps URNs to values of type V, where URNs are the same key when
+// they are equal under Equal for the map's comparison part and method.
+//
+// The zero value is an empty map comparing AssignedName with Simple.  A
+// nil *Map is an empty map too, for reading.  A Map is not safe for
+// concurrent use by multiple goroutines.
+type Map[V any] struct {
+	part   ComparisonPart
+	method ComparisonMethod
+	m      map[string]mapEntry[V] // by Key
+}
+
+type mapEntry[V any] struct {
+	urn   *URN
+	value V
+}
+
+// NewMap returns an empty map comparing URNs by part and method.
+func NewMap[V any](part ComparisonPart, method ComparisonMethod) *Map[V] {
+	return &Map[V]{part: part, method: method, m: make(map[string]mapEntry[V])}
+}
+
+// Part returns the comparison part of the map.
+func (m *Map[V]) Part() ComparisonPart {
+	if m == nil {
+		return AssignedName
+	}
+
+	return m.part
+}
+
+// Method returns the comparison method of the map.
+func (m *Map[V]) Method() ComparisonMethod {
+	if m == nil {
+		return Simple
+	}
+
+	return m.method
+}
+
+// Len returns the number of entries in the map.
+func (m *Map[V]) Len() int {
+	return len(m.entries())
+}
+
+// Set sets the value of the URN.  If the map already has an equal URN,
+// its value is replaced, keeping the URN set first.  A nil URN is
+// ignored.
+func (m *Map[V]) Set(u *URN, v V) {
+	if u == nil {
+		return
+	}
+
+	k := m.key(u)
+
+	if m.m == nil {
+		m.m = make(map[string]mapEntry[V])
+	}
+
+	if e, ok := m.m[k]; ok {
+		u = e.urn
+	}
+
+	m.m[k] = mapEntry[V]{urn: u, value: v}
+}
+
+// Get returns the value of the URN, and reports whether it was found.
+func (m *Map[V]) Get(u *URN) (V, bool) {
+	e, ok := m.entries()[m.key(u)]
+
+	return e.value, ok
+}
+
+// Delete removes the entry of the URN, and reports whether it was found.
+func (m *Map[V]) Delete(u *URN) bool {
+	k := m.key(u)
+
+	if _, ok := m.entries()[k]; !ok {
+		return false
+	}
+
+	delete(m.m, k)
+
+	return true
+}
+
+// URNs returns the keys of the map in canonical order, that is, sorted
+// as by Compare.
+func (m *Map[V]) URNs() []*URN {
+	entries := m.entries()
+	ids := make([]*URN, 0, len(entries))
+
+	for _, k := range sortedKeys(entries) {
+		ids = append(ids, entries[k].urn)
+	}
+
+	return ids
+}
+
+// Range calls fn for each entry of the map in canonical order, until fn
+// returns false.
+func (m *Map[V]) Range(fn func(u *URN, v V) bool) {
+	entries := m.entries()
+
+	for _, k := range sortedKeys(entries) {
+		e := entries[k]
+
+		if !fn(e.urn, e.value) {
+			return
+		}
+	}
+}
+
+// MarshalJSON marshals the map as a JSON object with URN strings as
+// names, in canonical order.
+func (m *Map[V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	entries := m.entries()
+
+	for i, k := range sortedKeys(entries) {
+		e := entries[k]
+
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		name, err := json.Marshal(e.urn.String())
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(e.value)
+		if err != nil {
+			return nil, fmt.Errorf("urn.Map.MarshalJSON: %s: %w", e.urn.String(), err)
+		}
+
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON sets the entries of a JSON object with URN strings as
+// names.  Names that are equal URNs are set in sorted order of the
+// names, so the value of the last one is kept.
+func (m *Map[V]) UnmarshalJSON(b []byte) error {
+	var raw map[string]json.RawMessage
+
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return fmt.Errorf("urn.Map.UnmarshalJSON: %w", err)
+	}
+
+	for _, name := range sortedKeys(raw) {
+		u, err := Parse(name)
+		if err != nil {
+			return fmt.Errorf("urn.Map.UnmarshalJSON: %w", err)
+		}
+
+		var v V
+
+		if err := json.Unmarshal(raw[name], &v); err != nil {
+			return fmt.Errorf("urn.Map.UnmarshalJSON: %s: %w", name, err)
+		}
+
+		m.Set(u, v)
+	}
+
+	return nil
+}
+
+func (m *Map[V]) key(u *URN) string {
+	return Key(u, m.Part(), m.Method())
+}
+
+// entries returns the entries by key, or nil if m is nil.
+func (m *Map[V]) entries() map[string]mapEntry[V] {
+	if m == nil {
+		return nil
+	}
+
+	return m.m
+}
@@ -0,0 +1,122 @@
+package urn_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/paulourio/go-urn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMap(t *testing.T) {
+	t.Parallel()
+
+	m := urn.NewMap[int](urn.AssignedName, urn.CaseNormalized)
+	ids := parseAll(t, "URN:IETF:rfc:8141", "urn:ietf:rfc:8141?=q", "urn:example:a", "urn:ietf:RFC:8141")
+
+	m.Set(ids[0], 1)
+	m.Set(ids[1], 2)
+	m.Set(ids[2], 3)
+	m.Set(ids[3], 4)
+
+	assert.Equal(t, urn.AssignedName, m.Part())
+	assert.Equal(t, urn.CaseNormalized, m.Method())
+	assert.Equal(t, 3, m.Len())
+	assert.Equal(t, []string{"urn:example:a", "urn:ietf:RFC:8141", "URN:IETF:rfc:8141"}, urnStrings(m.URNs()))
+
+	v, ok := m.Get(ids[1])
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+
+	var keys []string
+
+	var values []int
+
+	m.Range(func(u *urn.URN, v int) bool {
+		keys = append(keys, u.String())
+		values = append(values, v)
+
+		return true
+	})
+	assert.Equal(t, []string{"urn:example:a", "urn:ietf:RFC:8141", "URN:IETF:rfc:8141"}, keys)
+	assert.Equal(t, []int{3, 4, 2}, values)
+
+	assert.True(t, m.Delete(ids[0]))
+	assert.False(t, m.Delete(ids[1]))
+
+	_, ok = m.Get(ids[0])
+	assert.False(t, ok)
+	assert.Equal(t, 2, m.Len())
+}
+
+func TestMapZero(t *testing.T) {
+	t.Parallel()
+
+	var m urn.Map[string]
+
+	ids := parseAll(t, "urn:example:a", "URN:example:a")
+
+	_, ok := m.Get(ids[0])
+	assert.False(t, ok)
+	assert.False(t, m.Delete(ids[0]))
+
+	m.Set(ids[0], "x")
+	m.Set(ids[1], "y")
+	assert.Equal(t, 2, m.Len())
+}
+
+func TestMapNil(t *testing.T) {
+	t.Parallel()
+
+	var n *urn.Map[int]
+
+	u := parseAll(t, "urn:example:a")[0]
+
+	// A nil map is empty.
+	assert.Equal(t, 0, n.Len())
+	assert.Empty(t, n.URNs())
+	assert.False(t, n.Delete(u))
+	n.Range(func(*urn.URN, int) bool { t.Error("unexpected entry"); return true })
+
+	_, ok := n.Get(u)
+	assert.False(t, ok)
+
+	b, err := n.MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, `{}`, string(b))
+
+	// Nil URNs are not set.
+	m := urn.NewMap[int](urn.AssignedName, urn.CaseNormalized)
+	m.Set(nil, 1)
+	m.Set(u, 2)
+	assert.Equal(t, 1, m.Len())
+
+	_, ok = m.Get(nil)
+	assert.False(t, ok)
+
+	b, err = json.Marshal(m)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"urn:example:a":2}`, string(b))
+}
+
+func TestMapJSON(t *testing.T) {
+	t.Parallel()
+
+	type record struct {
+		Hits *urn.Map[int] `json:"hits"`
+	}
+
+	r := record{Hits: urn.NewMap[int](urn.AllParts, urn.CaseNormalized)}
+
+	input := `{"hits":{"urn:example:b":1,"URN:EXAMPLE:a":2,"urn:example:a":3,"urn:example:a?=q":4}}`
+
+	if assert.NoError(t, json.Unmarshal([]byte(input), &r)) {
+		b, err := json.Marshal(r)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"hits":{"URN:EXAMPLE:a":3,"urn:example:a?=q":4,"urn:example:b":1}}`, string(b))
+	}
+
+	assert.Error(t, json.Unmarshal([]byte(`{"hits":{"urn:a:b":1}}`), &r))
+	assert.Error(t, json.Unmarshal([]byte(`{"hits":{"urn:example:a":"x"}}`), &r))
+	assert.Error(t, json.Unmarshal([]byte(`{"hits":[]}`), &r))
+}
@@ -0,0 +1,188 @@
+package urn
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// A Namespace implements the rules specific to a URN namespace, such as
+// the syntax of its NSS and its lexical equivalence.
+//
+// [RFC 8141 §5](urn:ietf:rfc:8141#section-5):
+//
+//	A URN namespace is a collection of such URNs, each of which is (1)
+//	unique, (2) assigned in a consistent and managed way, and (3)
+//	assigned according to a common definition.
+type Namespace interface {
+	// NID returns the namespace identifier handled by the namespace.
+	NID() string
+
+	// Validate returns an error if the NSS, still percent-encoded,
+	// is not valid for the namespace.
+	Validate(nss string) error
+
+	// Normalize returns the canonical form of a valid NSS.  Two NSSs
+	// are lexically equivalent within the namespace if, and only if,
+	// their canonical forms are equal.
+	Normalize(nss string) string
+}
+
+// Registry is a set of namespaces indexed by their NID.  It is safe for
+// concurrent use by multiple goroutines.
+//
+// The package-level Parse checks only the generic URN syntax, and does
+// not consult any registry.  URNs are validated against the rules of
+// their namespace by Registry.Parse, Registry.Validate, or parsing with
+// the ValidateNamespaces option:
+//
+//	id, err := urn.ParseWithOptions(s, urn.ValidateNamespaces(urn.DefaultRegistry))
+type Registry struct {
+	mu sync.RWMutex
+	m  map[string]Namespace
+}
+
+// DefaultRegistry is the registry holding the namespaces implemented by
+// this package.  Namespace normalization, as in NamespaceNormalized,
+// looks up namespaces in it; parsing does not, unless asked to with
+// ValidateNamespaces.
+var DefaultRegistry = NewRegistry(
+	ExampleNamespace,
+	IETFNamespace,
+	ISBNNamespace,
+	ISSNNamespace,
+	OIDNamespace,
+	UUIDNamespace,
+)
+
+// NewRegistry returns a registry containing the given namespaces.  It
+// panics if any of the namespaces could not be registered.
+func NewRegistry(namespaces ...Namespace) *Registry {
+	r := &Registry{m: make(map[string]Namespace, len(namespaces))}
+
+	for _, ns := range namespaces {
+		if err := r.Register(ns); err != nil {
+			panic(err)
+		}
+	}
+
+	return r
+}
+
+// RegisterNamespace registers a namespace in the default registry.
+func RegisterNamespace(ns Namespace) error {
+	return DefaultRegistry.Register(ns)
+}
+
+// Register adds a namespace to the registry.  It fails if the NID of the
+// namespace is invalid or if it is already registered.
+func (r *Registry) Register(ns Namespace) error {
+	nid := ns.NID()
+
+	if !isValidNID(nid) {
+		return &Error{Op: "register", Data: nid, Err: ErrInvalidNID}
+	}
+
+	key := strings.ToLower(nid)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.m[key]; ok {
+		return &Error{Op: "register", Data: nid, Err: ErrDuplicateNamespace}
+	}
+
+	r.m[key] = ns
+
+	return nil
+}
+
+// Lookup returns the namespace registered for the NID, which is matched
+// case-insensitively.
+func (r *Registry) Lookup(nid string) (Namespace, bool) {
+	key := strings.ToLower(nid)
+
+	r.mu.RLock()
+	ns, ok := r.m[key]
+	r.mu.RUnlock()
+
+	return ns, ok
+}
+
+// Validate checks the NSS of the URN against the rules of its namespace.
+// URNs of namespaces not present in the registry are always valid.
+func (r *Registry) Validate(u *URN) error {
+	ns, ok := r.Lookup(u.NID)
+	if !ok {
+		return nil
+	}
+
+	if err := ns.Validate(u.NSS); err != nil {
+		return &Error{
+			Op:        "validate",
+			Data:      u.String(),
+			Err:       ErrInvalidNSS,
+			Msg:       fmt.Sprintf("%s: %s", strings.ToLower(u.NID), err),
+			Component: ComponentNSS,
+			Offset:    len(u.Scheme) + len(u.NID) + 2,
+			Length:    len(u.NSS),
+		}
+	}
+
+	return nil
+}
+
+// ValidateNamespaces returns an option validating the parsed URNs
+// against the rules of their namespace in the registry, as
+// Registry.Validate.
+func ValidateNamespaces(r *Registry) ParseOption {
+	return func(p *Parser) {
+		p.registry = r
+	}
+}
+
+// Parse parses a raw URN and validates it against the rules of its
+// namespace.
+func (r *Registry) Parse(s string) (*URN, error) {
+	u, err := Parse(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.Validate(u); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// Normalized returns a case-normalized copy of the URN whose NSS is
+// also normalized according to the rules of its namespace.  If the
+// namespace is not registered, or the NSS is not valid for it, only
+// case normalization is applied.
+func (r *Registry) Normalized(u *URN) *URN {
+	if u == nil {
+		return u
+	}
+
+	n := u.Copy()
+	n.Normalize()
+
+	if ns, ok := r.Lookup(n.NID); ok && ns.Validate(n.NSS) == nil {
+		n.NSS = ns.Normalize(n.NSS)
+	}
+
+	return n
+}
+
+// ExampleNamespace is the "example" namespace reserved for use in
+// documentation by [RFC 6963](urn:ietf:rfc:6963).  It accepts any NSS.
+var ExampleNamespace Namespace = exampleNamespace{}
+
+type exampleNamespace struct{}
+
+func (exampleNamespace) NID() string { return "example" }
+
+func (exampleNamespace) Validate(string) error { return nil }
+
+func (exampleNamespace) Normalize(nss string) string { return nss }
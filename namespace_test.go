@@ -0,0 +1,186 @@
+package urn_test
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/paulourio/go-urn"
+	"github.com/stretchr/testify/assert"
+)
+
+// digitsNamespace accepts only NSS made of decimal digits, ignoring
+// leading zeros for equivalence.
+type digitsNamespace struct{ nid string }
+
+func (d digitsNamespace) NID() string { return d.nid }
+
+func (d digitsNamespace) Validate(nss string) error {
+	if strings.Trim(nss, "0123456789") != "" {
+		return errors.New("expected only digits")
+	}
+
+	return nil
+}
+
+func (d digitsNamespace) Normalize(nss string) string {
+	if n := strings.TrimLeft(nss, "0"); n != "" {
+		return n
+	}
+
+	return "0"
+}
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	r := urn.NewRegistry(digitsNamespace{"digits"})
+
+	ns, ok := r.Lookup("DIGITS")
+	if assert.True(t, ok) {
+		assert.Equal(t, "digits", ns.NID())
+	}
+
+	_, ok = r.Lookup("example")
+	assert.False(t, ok)
+
+	u, err := r.Parse("urn:Digits:0123")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "0123", u.NSS)
+	}
+
+	// Namespaces not in the registry follow only the generic syntax.
+	_, err = r.Parse("urn:other:abc")
+	assert.NoError(t, err)
+
+	_, err = r.Parse("urn:digits:12a")
+	assert.ErrorIs(t, err, urn.ErrInvalidNSS)
+
+	_, err = r.Parse("urn:digits:")
+	assert.ErrorIs(t, err, urn.ErrInvalidNSS)
+
+	err = r.Register(digitsNamespace{"Digits"})
+	assert.ErrorIs(t, err, urn.ErrDuplicateNamespace)
+
+	for _, nid := range []string{"", "a", "-ab", "ab-", "a:b", "a b"} {
+		err = r.Register(digitsNamespace{nid})
+		assert.ErrorIs(t, err, urn.ErrInvalidNID, nid)
+	}
+}
+
+func TestRegistryConcurrent(t *testing.T) {
+	t.Parallel()
+
+	var wg sync.WaitGroup
+
+	r := urn.NewRegistry()
+	nids := []string{"aa", "bb", "cc", "dd", "ee", "ff", "gg", "hh"}
+
+	for _, nid := range nids {
+		wg.Add(2)
+
+		go func(nid string) {
+			defer wg.Done()
+			assert.NoError(t, r.Register(digitsNamespace{nid}))
+		}(nid)
+
+		go func(nid string) {
+			defer wg.Done()
+			_, _ = r.Parse("urn:" + nid + ":123")
+		}(nid)
+	}
+
+	wg.Wait()
+
+	for _, nid := range nids {
+		_, ok := r.Lookup(nid)
+		assert.True(t, ok, nid)
+	}
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	t.Parallel()
+
+	_, ok := urn.DefaultRegistry.Lookup("example")
+	assert.True(t, ok)
+
+	err := urn.RegisterNamespace(urn.ExampleNamespace)
+	assert.ErrorIs(t, err, urn.ErrDuplicateNamespace)
+}
+
+func TestValidateNamespaces(t *testing.T) {
+	t.Parallel()
+
+	r := urn.NewRegistry(digitsNamespace{"digits"})
+
+	// Parse checks only the generic syntax.
+	_, err := urn.Parse("urn:digits:12a")
+	assert.NoError(t, err)
+
+	u, err := urn.ParseWithOptions("urn:digits:12a", urn.ValidateNamespaces(r))
+	assert.ErrorIs(t, err, urn.ErrInvalidNSS)
+	assert.Nil(t, u)
+
+	u, err = urn.ParseWithOptions("urn:digits:0123", urn.ValidateNamespaces(r))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "0123", u.NSS)
+	}
+
+	var dst urn.URN
+
+	p := urn.NewParser(urn.ValidateNamespaces(urn.DefaultRegistry))
+	err = p.ParseInto(&dst, "urn:uuid:1234")
+	assert.ErrorIs(t, err, urn.ErrInvalidNSS)
+	assert.Equal(t, urn.URN{}, dst)
+
+	assert.NoError(t, p.ParseInto(&dst, "urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6"))
+	assert.Equal(t, "uuid", dst.NID)
+}
+
+func TestRegistryNormalized(t *testing.T) {
+	t.Parallel()
+
+	r := urn.NewRegistry(digitsNamespace{"digits"})
+
+	cases := []struct {
+		Input  string
+		Output string
+	}{
+		{"URN:Digits:000123?=a%2fb", "urn:digits:123?=a%2Fb"},
+		{"urn:digits:0", "urn:digits:0"},
+		{"urn:digits:000", "urn:digits:0"},
+		// Invalid NSS is only case-normalized.
+		{"urn:DIGITS:00a%2f", "urn:digits:00a%2F"},
+		// Unknown namespaces are only case-normalized.
+		{"urn:Other:000123", "urn:other:000123"},
+	}
+
+	for _, c := range cases {
+		u, err := urn.Parse(c.Input)
+		if assert.NoError(t, err, c.Input) {
+			n := r.Normalized(u)
+			assert.Equal(t, c.Output, n.String(), c.Input)
+			assert.True(t, n.IsNormalized())
+			assert.Equal(t, c.Input, u.String(), "original must not change")
+		}
+	}
+
+	assert.Nil(t, r.Normalized(nil))
+}
+
+func TestEqualNamespaceNormalized(t *testing.T) {
+	t.Parallel()
+
+	// Ignore duplicate errors when running with -count.
+	_ = urn.RegisterNamespace(digitsNamespace{"test-digits"})
+
+	a, _ := urn.Parse("urn:TEST-DIGITS:0042?=x")
+	b, _ := urn.Parse("urn:test-digits:42?=y")
+	c, _ := urn.Parse("urn:test-digits:43")
+
+	assert.True(t, urn.Equal(a, b, urn.AssignedName, urn.NamespaceNormalized))
+	assert.False(t, urn.Equal(a, b, urn.AllParts, urn.NamespaceNormalized))
+	assert.False(t, urn.Equal(a, c, urn.AssignedName, urn.NamespaceNormalized))
+	assert.False(t, urn.Equal(a, b, urn.AssignedName, urn.CaseNormalized))
+}
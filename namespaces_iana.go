@@ -0,0 +1,80 @@
+// Code generated by gen_namespaces.go from testdata/urn-namespaces.xml; DO NOT EDIT.
+
+package urn
+
+// ianaUpdated is the date of the registry snapshot, or empty if the
+// snapshot is an excerpt.
+const ianaUpdated = "2024-11-04"
+
+var ianaNamespaces = [...]NamespaceInfo{
+	{NID: "3gpp", Kind: NIDFormal, Reference: "RFC 5279", Registrant: "", Status: "active"},
+	{NID: "3gpp2", Kind: NIDFormal, Reference: "", Registrant: "3rd Generation Partnership Project 2", Status: "active"},
+	{NID: "adid", Kind: NIDFormal, Reference: "", Registrant: "Ad-ID", Status: "active"},
+	{NID: "alert", Kind: NIDFormal, Reference: "RFC 7462", Registrant: "", Status: "active"},
+	{NID: "bbf", Kind: NIDFormal, Reference: "", Registrant: "Broadband Forum", Status: "active"},
+	{NID: "broadband-forum-org", Kind: NIDFormal, Reference: "RFC 6289", Registrant: "", Status: "active"},
+	{NID: "cablelabs", Kind: NIDFormal, Reference: "", Registrant: "Cable Television Laboratories", Status: "active"},
+	{NID: "ccsds", Kind: NIDFormal, Reference: "", Registrant: "Consultative Committee for Space Data Systems", Status: "active"},
+	{NID: "clei", Kind: NIDFormal, Reference: "RFC 4152", Registrant: "", Status: "active"},
+	{NID: "dev", Kind: NIDFormal, Reference: "RFC 9039", Registrant: "", Status: "active"},
+	{NID: "dgiwg", Kind: NIDFormal, Reference: "RFC 6288", Registrant: "", Status: "active"},
+	{NID: "dvb", Kind: NIDFormal, Reference: "RFC 7354", Registrant: "", Status: "active"},
+	{NID: "ebu", Kind: NIDFormal, Reference: "RFC 5174", Registrant: "", Status: "active"},
+	{NID: "eidr", Kind: NIDFormal, Reference: "RFC 7972", Registrant: "", Status: "active"},
+	{NID: "epc", Kind: NIDFormal, Reference: "RFC 5134", Registrant: "", Status: "active"},
+	{NID: "epcglobal", Kind: NIDFormal, Reference: "RFC 5134", Registrant: "", Status: "active"},
+	{NID: "eurosystem", Kind: NIDFormal, Reference: "RFC 7207", Registrant: "", Status: "active"},
+	{NID: "example", Kind: NIDFormal, Reference: "RFC 6963", Registrant: "", Status: "active"},
+	{NID: "fdc", Kind: NIDFormal, Reference: "RFC 4198", Registrant: "", Status: "active"},
+	{NID: "fipa", Kind: NIDFormal, Reference: "RFC 3616", Registrant: "", Status: "active"},
+	{NID: "geant", Kind: NIDFormal, Reference: "RFC 4926", Registrant: "", Status: "active"},
+	{NID: "gsma", Kind: NIDFormal, Reference: "RFC 7254", Registrant: "", Status: "active"},
+	{NID: "hbbtv", Kind: NIDFormal, Reference: "", Registrant: "HbbTV Association", Status: "active"},
+	{NID: "ieee", Kind: NIDFormal, Reference: "RFC 8069", Registrant: "", Status: "active"},
+	{NID: "ietf", Kind: NIDFormal, Reference: "RFC 2648", Registrant: "", Status: "active"},
+	{NID: "iptc", Kind: NIDFormal, Reference: "RFC 3937", Registrant: "", Status: "active"},
+	{NID: "isan", Kind: NIDFormal, Reference: "RFC 4246", Registrant: "", Status: "active"},
+	{NID: "isbn", Kind: NIDFormal, Reference: "RFC 8254", Registrant: "International ISBN Agency", Status: "active"},
+	{NID: "iso", Kind: NIDFormal, Reference: "RFC 5141", Registrant: "", Status: "active"},
+	{NID: "issn", Kind: NIDFormal, Reference: "RFC 8254", Registrant: "ISSN International Centre", Status: "active"},
+	{NID: "ivis", Kind: NIDFormal, Reference: "RFC 4617", Registrant: "", Status: "active"},
+	{NID: "lex", Kind: NIDFormal, Reference: "", Registrant: "", Status: "active"},
+	{NID: "liberty", Kind: NIDFormal, Reference: "RFC 3622", Registrant: "", Status: "active"},
+	{NID: "mace", Kind: NIDFormal, Reference: "RFC 3613", Registrant: "", Status: "active"},
+	{NID: "mef", Kind: NIDFormal, Reference: "", Registrant: "MEF Forum", Status: "active"},
+	{NID: "mpeg", Kind: NIDFormal, Reference: "RFC 3614", Registrant: "", Status: "active"},
+	{NID: "mrn", Kind: NIDFormal, Reference: "", Registrant: "International Association of Marine Aids to Navigation and Lighthouse Authorities", Status: "active"},
+	{NID: "nato", Kind: NIDFormal, Reference: "RFC 7467", Registrant: "", Status: "active"},
+	{NID: "nbn", Kind: NIDFormal, Reference: "RFC 8458", Registrant: "", Status: "active"},
+	{NID: "nena", Kind: NIDFormal, Reference: "RFC 6061", Registrant: "", Status: "active"},
+	{NID: "newsml", Kind: NIDFormal, Reference: "RFC 3085", Registrant: "", Status: "active"},
+	{NID: "nfc", Kind: NIDFormal, Reference: "RFC 4729", Registrant: "", Status: "active"},
+	{NID: "nzl", Kind: NIDFormal, Reference: "RFC 4350", Registrant: "", Status: "active"},
+	{NID: "oasis", Kind: NIDFormal, Reference: "RFC 3121", Registrant: "", Status: "active"},
+	{NID: "ogc", Kind: NIDFormal, Reference: "RFC 5165", Registrant: "", Status: "active"},
+	{NID: "ogf", Kind: NIDFormal, Reference: "RFC 6453", Registrant: "", Status: "active"},
+	{NID: "oid", Kind: NIDFormal, Reference: "RFC 3061", Registrant: "", Status: "active"},
+	{NID: "oipf", Kind: NIDFormal, Reference: "RFC 6893", Registrant: "", Status: "active"},
+	{NID: "oma", Kind: NIDFormal, Reference: "RFC 4358", Registrant: "", Status: "active"},
+	{NID: "pin", Kind: NIDFormal, Reference: "RFC 3043", Registrant: "", Status: "active"},
+	{NID: "publicid", Kind: NIDFormal, Reference: "RFC 3151", Registrant: "", Status: "active"},
+	{NID: "s1000d", Kind: NIDFormal, Reference: "RFC 4688", Registrant: "", Status: "active"},
+	{NID: "schac", Kind: NIDFormal, Reference: "RFC 6338", Registrant: "", Status: "active"},
+	{NID: "service", Kind: NIDFormal, Reference: "RFC 5031", Registrant: "", Status: "active"},
+	{NID: "smpte", Kind: NIDFormal, Reference: "RFC 5119", Registrant: "", Status: "active"},
+	{NID: "swift", Kind: NIDFormal, Reference: "RFC 3615", Registrant: "", Status: "active"},
+	{NID: "tva", Kind: NIDFormal, Reference: "RFC 4195", Registrant: "", Status: "active"},
+	{NID: "uci", Kind: NIDFormal, Reference: "RFC 4179", Registrant: "", Status: "active"},
+	{NID: "ucode", Kind: NIDFormal, Reference: "RFC 6588", Registrant: "", Status: "active"},
+	{NID: "urn-1", Kind: NIDInformal, Reference: "", Registrant: "", Status: "active"},
+	{NID: "urn-2", Kind: NIDInformal, Reference: "", Registrant: "", Status: "active"},
+	{NID: "urn-3", Kind: NIDInformal, Reference: "", Registrant: "", Status: "active"},
+	{NID: "urn-4", Kind: NIDInformal, Reference: "", Registrant: "", Status: "active"},
+	{NID: "urn-5", Kind: NIDInformal, Reference: "", Registrant: "", Status: "active"},
+	{NID: "urn-6", Kind: NIDInformal, Reference: "", Registrant: "", Status: "active"},
+	{NID: "urn-7", Kind: NIDInformal, Reference: "", Registrant: "", Status: "active"},
+	{NID: "uuid", Kind: NIDFormal, Reference: "RFC 9562", Registrant: "", Status: "active"},
+	{NID: "web3d", Kind: NIDFormal, Reference: "RFC 3541", Registrant: "", Status: "active"},
+	{NID: "xmlorg", Kind: NIDFormal, Reference: "RFC 3120", Registrant: "", Status: "active"},
+	{NID: "xmpp", Kind: NIDFormal, Reference: "RFC 4854", Registrant: "", Status: "active"},
+}
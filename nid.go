@@ -0,0 +1,120 @@
+package urn
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NIDKind classifies a Namespace Identifier according to the policy of
+// [RFC 8141 §5](urn:ietf:rfc:8141#section-5).
+type NIDKind int
+
+const (
+	// NIDFormal is a formal NID, such as "isbn" or "uuid", registered
+	// with IANA.
+	NIDFormal NIDKind = iota
+	// NIDInformal is an informal NID of the form "urn-<number>".
+	NIDInformal
+	// NIDExperimental is an experimental NID of the form "X-<name>",
+	// from RFC 3406.  RFC 8141 removed experimental NIDs, and they are
+	// not to be used anymore.
+	NIDExperimental
+	// NIDExample is the "example" NID, reserved for documentation by
+	// RFC 6963.
+	NIDExample
+	// NIDReserved is a NID that cannot be assigned: "urn" itself, and
+	// names starting with "urn-" that are not informal NIDs.
+	NIDReserved
+)
+
+func (k NIDKind) String() string {
+	switch k {
+	case NIDFormal:
+		return "formal"
+	case NIDInformal:
+		return "informal"
+	case NIDExperimental:
+		return "experimental"
+	case NIDExample:
+		return "example"
+	case NIDReserved:
+		return "reserved"
+	}
+
+	return fmt.Sprintf("NIDKind(%d)", int(k))
+}
+
+// NIDKind returns the kind of the Namespace Identifier of the URN.
+func (u *URN) NIDKind() NIDKind {
+	return nidKind(u.NID)
+}
+
+// StrictNID makes a parser reject NIDs that RFC 8141 disallows:
+// experimental NIDs and reserved NIDs.  Informal NIDs and the "example"
+// NID are accepted.
+func StrictNID() ParseOption {
+	return func(p *Parser) {
+		p.strict = true
+	}
+}
+
+// nidKind classifies a syntactically valid NID.
+//
+// [RFC 8141 §5.2](urn:ietf:rfc:8141#section-5.2):
+//
+//	Informal namespaces are full-fledged URN namespaces, with all the
+//	associated rights and responsibilities. Informal namespaces differ
+//	from formal namespaces in the process for assigning a NID: for an
+//	informal namespace, the registrant does not designate the NID;
+//	instead, IANA assigns a NID consisting of the string 'urn-' followed
+//	by one or more digits.
+func nidKind(nid string) NIDKind {
+	switch {
+	case strings.EqualFold(nid, "urn"):
+		return NIDReserved
+	case strings.EqualFold(nid, "example"):
+		return NIDExample
+	case len(nid) > 2 && nid[1] == '-' && nid[0]|0x20 == 'x':
+		return NIDExperimental
+	case len(nid) >= 4 && strings.EqualFold(nid[:4], "urn-"):
+		if isInformalNumber(nid[4:]) {
+			return NIDInformal
+		}
+
+		return NIDReserved
+	}
+
+	return NIDFormal
+}
+
+// isInformalNumber reports whether s is a decimal number assigned to
+// an informal namespace, without leading zeros.
+func isInformalNumber(s string) bool {
+	if s == "" || s[0] == '0' {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// checkNIDKind rejects the NID just consumed if the parser is strict
+// and the NID is not allowed by RFC 8141.
+func (p *parser) checkNIDKind() error {
+	if !p.strict {
+		return nil
+	}
+
+	switch kind := nidKind(p.id.NID); kind {
+	case NIDExperimental, NIDReserved:
+		return p.newErr(ErrInvalidNID, ComponentNID, len(p.id.Scheme)+1, len(p.id.NID),
+			kind.String()+" NID")
+	}
+
+	return nil
+}
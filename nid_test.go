@@ -0,0 +1,83 @@
+package urn_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/paulourio/go-urn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNIDKind(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Input string
+		Kind  urn.NIDKind
+	}{
+		{"urn:isbn:0451450523", urn.NIDFormal},
+		{"urn:ietf:rfc:2648", urn.NIDFormal},
+		{"urn:xy:z", urn.NIDFormal},
+		{"urn:urn-7:foo", urn.NIDInformal},
+		{"urn:URN-123:foo", urn.NIDInformal},
+		{"urn:X-dns-2:foo", urn.NIDExperimental},
+		{"urn:x-foo:bar", urn.NIDExperimental},
+		{"urn:example:foo", urn.NIDExample},
+		{"urn:Example:foo", urn.NIDExample},
+		{"urn:urn:foo", urn.NIDReserved},
+		{"urn:urn-foo:bar", urn.NIDReserved},
+		{"urn:urn-07:bar", urn.NIDReserved},
+		{"urn:urn-7a:bar", urn.NIDReserved},
+	}
+
+	for i, c := range cases {
+		msg := fmt.Sprintf("case %d: %q", i+1, c.Input)
+
+		id, err := urn.Parse(c.Input)
+		if assert.NoError(t, err, msg) {
+			assert.Equal(t, c.Kind, id.NIDKind(), msg)
+		}
+	}
+}
+
+func TestParseStrictNID(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Input string
+		Err   error
+	}{
+		{"urn:isbn:0451450523", nil},
+		{"urn:urn-7:foo", nil},
+		{"urn:example:foo", nil},
+		{"urn:X-dns-2:foo", urn.ErrInvalidNID},
+		{"urn:urn:foo", urn.ErrInvalidNID},
+		{"urn:urn-foo:bar", urn.ErrInvalidNID},
+		{"urn:urn-0:bar", urn.ErrInvalidNID},
+	}
+
+	for i, c := range cases {
+		msg := fmt.Sprintf("case %d: %q", i+1, c.Input)
+
+		// Without the option, all are valid.
+		_, err := urn.Parse(c.Input)
+		assert.NoError(t, err, msg)
+
+		id, err := urn.ParseWithOptions(c.Input, urn.StrictNID())
+		if c.Err == nil {
+			assert.NoError(t, err, msg)
+			assert.NotNil(t, id, msg)
+
+			continue
+		}
+
+		assert.ErrorIs(t, err, c.Err, msg)
+		assert.Nil(t, id, msg)
+
+		var uerr *urn.Error
+		if assert.ErrorAs(t, err, &uerr, msg) {
+			assert.Equal(t, urn.ComponentNID, uerr.Component, msg)
+			assert.Equal(t, 4, uerr.Offset, msg)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package urn
+
+import (
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// OIDNamespace implements the "oid" namespace from
+// [RFC 3061](urn:ietf:rfc:3061), whose NSS is an ISO/IEC object
+// identifier in dotted-decimal notation, such as "1.3.6.1".
+//
+// The NSS follows the grammar of [RFC 3061 §2](urn:ietf:rfc:3061#section-2),
+// where LEADDIGIT is a digit other than "0":
+//
+//	NSS    = oid
+//	oid    = number *( "." number )
+//	number = DIGIT / ( LEADDIGIT 1*DIGIT )
+var OIDNamespace Namespace = oidNamespace{}
+
+type oidNamespace struct{}
+
+func (oidNamespace) NID() string { return "oid" }
+
+func (oidNamespace) Validate(nss string) error {
+	return validateOID(nss)
+}
+
+func (oidNamespace) Normalize(nss string) string { return nss }
+
+// OIDFromURN returns the object identifier of an "oid" URN.
+func OIDFromURN(u *URN) (asn1.ObjectIdentifier, error) {
+	if !strings.EqualFold(u.NID, "oid") {
+		return nil, &Error{
+			Op:   "oid",
+			Data: u.String(),
+			Err:  ErrInvalidNID,
+			Msg:  "not an oid namespace",
+		}
+	}
+
+	if err := validateOID(u.NSS); err != nil {
+		return nil, &Error{Op: "oid", Data: u.String(), Err: ErrInvalidNSS, Msg: err.Error()}
+	}
+
+	arcs := strings.Split(u.NSS, ".")
+	oid := make(asn1.ObjectIdentifier, len(arcs))
+
+	for i, arc := range arcs {
+		n, err := strconv.Atoi(arc)
+		if err != nil {
+			return nil, &Error{
+				Op:   "oid",
+				Data: u.String(),
+				Err:  ErrInvalidNSS,
+				Msg:  fmt.Sprintf("arc %d out of range", i+1),
+			}
+		}
+
+		oid[i] = n
+	}
+
+	return oid, nil
+}
+
+// URNFromOID returns the "oid" URN of an object identifier.
+func URNFromOID(oid asn1.ObjectIdentifier) (*URN, error) {
+	nss := oid.String()
+
+	if err := validateOID(nss); err != nil {
+		return nil, &Error{Op: "oid", Data: nss, Err: ErrInvalidNSS, Msg: err.Error()}
+	}
+
+	return &URN{Scheme: "urn", NID: "oid", NSS: nss, normalized: true}, nil
+}
+
+// OIDDER returns the DER encoding of the object identifier of an "oid"
+// URN, including its tag and length.  Object identifiers with less than
+// two arcs cannot be encoded.
+func OIDDER(u *URN) ([]byte, error) {
+	oid, err := OIDFromURN(u)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := asn1.Marshal(oid)
+	if err != nil {
+		return nil, &Error{Op: "oid", Data: u.String(), Err: ErrInvalidNSS, Msg: err.Error()}
+	}
+
+	return b, nil
+}
+
+func validateOID(s string) error {
+	if s == "" {
+		return errors.New("empty identifier")
+	}
+
+	for i, arc := range strings.Split(s, ".") {
+		if arc == "" {
+			return fmt.Errorf("empty arc %d", i+1)
+		}
+
+		for j := 0; j < len(arc); j++ {
+			if !isDigit(arc[j]) {
+				return fmt.Errorf("invalid byte in arc %d", i+1)
+			}
+		}
+
+		if len(arc) > 1 && arc[0] == '0' {
+			return fmt.Errorf("leading zero in arc %d", i+1)
+		}
+
+		switch i {
+		case 0:
+			if len(arc) > 1 || arc[0] > '2' {
+				return errors.New("first arc must be 0, 1 or 2")
+			}
+		case 1:
+			// Arcs under the first two roots are limited to 0-39,
+			// see ITU-T X.660.
+			if s[0] != '2' && (len(arc) > 2 || (len(arc) == 2 && arc > "39")) {
+				return errors.New("second arc must be at most 39")
+			}
+		}
+	}
+
+	return nil
+}
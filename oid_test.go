@@ -0,0 +1,113 @@
+package urn_test
+
+import (
+	"encoding/asn1"
+	"fmt"
+	"testing"
+
+	"github.com/paulourio/go-urn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOIDFromURN(t *testing.T) {
+	t.Parallel()
+
+	for i, c := range oidCases {
+		msg := fmt.Sprintf("case %d: %q", i+1, c.Input)
+
+		u, err := urn.Parse(c.Input)
+		if !assert.NoError(t, err, msg) {
+			continue
+		}
+
+		oid, err := urn.OIDFromURN(u)
+		if c.Err != nil {
+			assert.ErrorIs(t, err, c.Err, msg)
+			assert.ErrorIs(t, urn.DefaultRegistry.Validate(u), c.Err, msg)
+
+			continue
+		}
+
+		if assert.NoError(t, err, msg) {
+			assert.Equal(t, c.OID, oid, msg)
+			assert.NoError(t, urn.DefaultRegistry.Validate(u), msg)
+
+			back, err := urn.URNFromOID(oid)
+			if assert.NoError(t, err, msg) {
+				assert.Equal(t, "urn:oid:"+u.NSS, back.String(), msg)
+			}
+		}
+	}
+}
+
+func TestOIDFromURNOverflow(t *testing.T) {
+	t.Parallel()
+
+	// Valid in the namespace, but does not fit an ObjectIdentifier.
+	u, _ := urn.Parse("urn:oid:2.1.99999999999999999999")
+	assert.NoError(t, urn.DefaultRegistry.Validate(u))
+
+	_, err := urn.OIDFromURN(u)
+	assert.ErrorIs(t, err, urn.ErrInvalidNSS)
+}
+
+func TestURNFromOID(t *testing.T) {
+	t.Parallel()
+
+	invalid := []asn1.ObjectIdentifier{
+		nil,
+		{3, 1},
+		{1, 40},
+		{1, -1},
+	}
+
+	for _, oid := range invalid {
+		_, err := urn.URNFromOID(oid)
+		assert.ErrorIs(t, err, urn.ErrInvalidNSS, oid.String())
+	}
+}
+
+func TestOIDDER(t *testing.T) {
+	t.Parallel()
+
+	u, _ := urn.Parse("urn:oid:1.2.840.113549")
+
+	der, err := urn.OIDDER(u)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []byte{0x06, 0x06, 0x2a, 0x86, 0x48, 0x86, 0xf7, 0x0d}, der)
+	}
+
+	u, _ = urn.Parse("urn:oid:2")
+	_, err = urn.OIDDER(u)
+	assert.ErrorIs(t, err, urn.ErrInvalidNSS)
+
+	u, _ = urn.Parse("urn:uuid:1.2")
+	_, err = urn.OIDDER(u)
+	assert.ErrorIs(t, err, urn.ErrInvalidNID)
+}
+
+type oidTestCase struct {
+	Input string
+	OID   asn1.ObjectIdentifier
+	Err   error
+}
+
+var oidCases = []*oidTestCase{
+	{Input: "urn:oid:1.3.6.1", OID: asn1.ObjectIdentifier{1, 3, 6, 1}},
+	{Input: "urn:OID:2.16.840", OID: asn1.ObjectIdentifier{2, 16, 840}},
+	{Input: "urn:oid:0.0", OID: asn1.ObjectIdentifier{0, 0}},
+	{Input: "urn:oid:1.39.0", OID: asn1.ObjectIdentifier{1, 39, 0}},
+	{Input: "urn:oid:2.999.1", OID: asn1.ObjectIdentifier{2, 999, 1}},
+	{Input: "urn:oid:2", OID: asn1.ObjectIdentifier{2}},
+	{Input: "urn:oid:3.1", Err: urn.ErrInvalidNSS},
+	{Input: "urn:oid:10.1", Err: urn.ErrInvalidNSS},
+	{Input: "urn:oid:1.40", Err: urn.ErrInvalidNSS},
+	{Input: "urn:oid:0.100", Err: urn.ErrInvalidNSS},
+	{Input: "urn:oid:1.03.6", Err: urn.ErrInvalidNSS},
+	{Input: "urn:oid:1.3.06", Err: urn.ErrInvalidNSS},
+	{Input: "urn:oid:1..3", Err: urn.ErrInvalidNSS},
+	{Input: "urn:oid:1.3.", Err: urn.ErrInvalidNSS},
+	{Input: "urn:oid:.1.3", Err: urn.ErrInvalidNSS},
+	{Input: "urn:oid:1.3.a", Err: urn.ErrInvalidNSS},
+	{Input: "urn:oid:1.3%2E6", Err: urn.ErrInvalidNSS},
+}
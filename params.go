@@ -0,0 +1,242 @@
+package urn
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// A Param is a key/value pair of a component.
+type Param struct {
+	Key   string
+	Value string
+
+	// NoValue reports that the pair had no '=', as in "key" rather
+	// than "key=".  Such a pair is encoded without '=' when Value is
+	// empty.
+	NoValue bool
+}
+
+// Params is an ordered list of key/value pairs, in the "key=value&..."
+// form commonly used in r-components and q-components.
+//
+// Unlike url.Values, keys keep their order and values are decoded using
+// only percent-encoding: a '+' is a plus sign, not a space.
+type Params []Param
+
+// ParseParams decodes a percent-encoded "key=value&..." string.  Empty
+// pairs are skipped, and a pair without '=' has an empty value and
+// NoValue set, so that it encodes back without '='.  It fails if a '%' is not followed by two hexadecimal digits.
+func ParseParams(s string) (Params, error) {
+	if i := invalidEscape(s); i >= 0 {
+		return nil, escapeError("parse params", s, i)
+	}
+
+	return parseParams(s), nil
+}
+
+// parseParams decodes parameters as ParseParams, keeping invalid escapes
+// as is.
+func parseParams(s string) Params {
+	if s == "" {
+		return nil
+	}
+
+	p := make(Params, 0, strings.Count(s, "&")+1)
+
+	for _, pair := range strings.Split(s, "&") {
+		if pair == "" {
+			continue
+		}
+
+		key, value, found := strings.Cut(pair, "=")
+
+		p = append(p, Param{
+			Key:     string(Decode(key)),
+			Value:   string(Decode(value)),
+			NoValue: !found,
+		})
+	}
+
+	return p
+}
+
+// ParamsFromValues converts url.Values to Params ordered by key.
+func ParamsFromValues(v url.Values) Params {
+	keys := make([]string, 0, len(v))
+	for k := range v {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	var p Params
+
+	for _, k := range keys {
+		for _, value := range v[k] {
+			p = append(p, Param{Key: k, Value: value})
+		}
+	}
+
+	return p
+}
+
+// Get returns the first value associated with the key, or an empty
+// string if there is none.
+func (p Params) Get(key string) string {
+	for _, kv := range p {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+
+	return ""
+}
+
+// Has reports whether the key is present.
+func (p Params) Has(key string) bool {
+	for _, kv := range p {
+		if kv.Key == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Values returns all values associated with the key, in order.
+func (p Params) Values(key string) []string {
+	var values []string
+
+	for _, kv := range p {
+		if kv.Key == key {
+			values = append(values, kv.Value)
+		}
+	}
+
+	return values
+}
+
+// Add appends the key/value pair.
+func (p *Params) Add(key, value string) {
+	*p = append(*p, Param{Key: key, Value: value})
+}
+
+// Set replaces the value of the first pair of the key, removing any
+// other pair of the same key.  If the key is not present, the pair is
+// appended.
+func (p *Params) Set(key, value string) {
+	found := false
+	out := (*p)[:0]
+
+	for _, kv := range *p {
+		if kv.Key == key {
+			if found {
+				continue
+			}
+
+			kv.Value, kv.NoValue = value, false
+			found = true
+		}
+
+		out = append(out, kv)
+	}
+
+	if !found {
+		out = append(out, Param{Key: key, Value: value})
+	}
+
+	*p = out
+}
+
+// Del removes all pairs of the key.
+func (p *Params) Del(key string) {
+	out := (*p)[:0]
+
+	for _, kv := range *p {
+		if kv.Key != key {
+			out = append(out, kv)
+		}
+	}
+
+	*p = out
+}
+
+// URLValues converts the parameters to url.Values, which loses the
+// order of keys.
+func (p Params) URLValues() url.Values {
+	v := make(url.Values, len(p))
+
+	for _, kv := range p {
+		v[kv.Key] = append(v[kv.Key], kv.Value)
+	}
+
+	return v
+}
+
+// Encode encodes the parameters in order, suitable for use as a
+// q-component.
+func (p Params) Encode() string {
+	return p.encode(queryParamEncoder)
+}
+
+func (p Params) encode(e *Encoder) string {
+	var b strings.Builder
+
+	for i, kv := range p {
+		if i > 0 {
+			b.WriteByte('&')
+		}
+
+		b.WriteString(e.Encode([]byte(kv.Key)))
+
+		if kv.NoValue && kv.Value == "" {
+			continue
+		}
+
+		b.WriteByte('=')
+		b.WriteString(e.Encode([]byte(kv.Value)))
+	}
+
+	return escapeLeading(b.String())
+}
+
+// escapeLeading percent-encodes a leading '/' or '?', which components
+// may contain but cannot start with.
+func escapeLeading(s string) string {
+	if s != "" && (s[0] == '/' || s[0] == '?') {
+		return "%" + string(upperHex[s[0]>>4]) + string(upperHex[s[0]&0xF]) + s[1:]
+	}
+
+	return s
+}
+
+var (
+	// Inside r-components, a "?=" sequence would start a q-component.
+	resolveParamEncoder = NewEncoder(WithKeepUnescaped('/'), WithEscaped('&', '='))
+	queryParamEncoder   = NewEncoder(WithKeepUnescaped('/', '?'), WithEscaped('&', '='))
+)
+
+// QueryValues decodes the q-component as key/value parameters.  Invalid
+// percent-encodings, which parsed URNs do not have, are kept as is.
+func (u *URN) QueryValues() Params {
+	return parseParams(u.Query)
+}
+
+// ResolveValues decodes the r-component as key/value parameters.
+// Invalid percent-encodings, which parsed URNs do not have, are kept as
+// is.
+func (u *URN) ResolveValues() Params {
+	return parseParams(u.Resolve)
+}
+
+// SetQueryValues replaces the q-component with the encoded parameters.
+func (u *URN) SetQueryValues(p Params) {
+	u.Query = p.encode(queryParamEncoder)
+}
+
+// SetResolveValues replaces the r-component with the encoded
+// parameters.
+func (u *URN) SetResolveValues(p Params) {
+	u.Resolve = p.encode(resolveParamEncoder)
+}
@@ -1,19 +1,143 @@
 package urn
 
-import "fmt"
+import (
+	"fmt"
+	"unsafe"
+)
 
 const MaxLenNID = 32
 
-// Parse parses a raw URN into a URN identifier structure.
+// Parse parses a raw URN into a URN identifier structure.  It checks
+// only the generic URN syntax: the rules of namespaces, even those in
+// the DefaultRegistry, apply with Registry.Parse or the
+// ValidateNamespaces option.
 func Parse(s string) (*URN, error) {
-	p := &parser{
-		source: s,
-		n:      len(s),
-		id:     &URN{},
-		offset: 0,
+	u := &URN{}
+
+	if err := ParseInto(u, s); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// ParseBytes parses a raw URN from a byte slice into a URN identifier
+// structure.  The URN does not retain the slice.
+func ParseBytes(b []byte) (*URN, error) {
+	return Parse(string(b))
+}
+
+// ParseInto parses a raw URN into the caller-owned dst, overwriting all
+// of its fields, without allocating memory other than for errors.  The
+// components of dst are substrings of s.  On error, dst is zeroed.
+func ParseInto(dst *URN, s string) error {
+	var p Parser
+
+	return p.ParseInto(dst, s)
+}
+
+// ParseWithOptions parses a raw URN into a URN identifier structure,
+// with the given options.
+//
+//	id, err := urn.ParseWithOptions(s, urn.Syntax(urn.RFC2141))
+func ParseWithOptions(s string, opts ...ParseOption) (*URN, error) {
+	return NewParser(opts...).Parse(s)
+}
+
+// A ParseOption configures a Parser.
+type ParseOption func(*Parser)
+
+// A Parser parses URNs with a set of options.  Its only state kept
+// between calls is the memory blocks that ParseBytes copies byte inputs
+// to, which it allocates once per block rather than once per input.
+// ParseInto does not allocate, but for errors.  The zero value is ready
+// to use, and parses with the RFC 8141 syntax.  A Parser is not safe for
+// concurrent use by multiple goroutines.
+type Parser struct {
+	syntax   SyntaxVersion
+	strict   bool
+	registry *Registry
+
+	// arena holds copies of byte inputs.  Parsed URNs point into it,
+	// so written bytes are never modified: a new block is allocated
+	// when the current one is full.
+	arena []byte
+}
+
+// parserArenaSize is the size of blocks a Parser copies byte inputs to.
+// Inputs larger than a quarter of a block are copied on their own.
+const parserArenaSize = 16 * 1024
+
+// NewParser returns a new parser for the given options.
+func NewParser(opts ...ParseOption) *Parser {
+	p := &Parser{}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Parse parses a raw URN into a new URN identifier structure.
+func (p *Parser) Parse(s string) (*URN, error) {
+	u := &URN{}
+
+	if err := p.ParseInto(u, s); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// ParseInto parses a raw URN into dst, as the package-level ParseInto.
+func (p *Parser) ParseInto(dst *URN, s string) error {
+	ps := parser{source: s, n: len(s), id: dst, syntax: p.syntax, strict: p.strict}
+
+	if err := ps.parse(); err != nil {
+		return err
+	}
+
+	if p.registry != nil {
+		if err := p.registry.Validate(dst); err != nil {
+			*dst = URN{}
+
+			return err
+		}
 	}
 
-	return p.Parse()
+	return nil
+}
+
+// ParseBytes parses a raw URN from a byte slice into dst.  The URN does
+// not retain the slice: its bytes are copied to memory blocks shared by
+// the URNs parsed by the Parser, so that parsing does not allocate but
+// once per block.  A block is kept alive while any URN parsed into it
+// is reachable.
+func (p *Parser) ParseBytes(dst *URN, b []byte) error {
+	return p.ParseInto(dst, p.copyBytes(b))
+}
+
+func (p *Parser) copyBytes(b []byte) string {
+	n := len(b)
+
+	if n == 0 {
+		return ""
+	}
+
+	if n > parserArenaSize/4 {
+		return string(b)
+	}
+
+	if cap(p.arena)-len(p.arena) < n {
+		p.arena = make([]byte, 0, parserArenaSize)
+	}
+
+	start := len(p.arena)
+	p.arena = append(p.arena, b...)
+	data := p.arena[start : start+n : start+n]
+
+	return *(*string)(unsafe.Pointer(&data))
 }
 
 type parser struct {
@@ -21,52 +145,98 @@ type parser struct {
 	source string
 	n      int
 
+	// Options
+	syntax SyntaxVersion
+	strict bool // reject experimental and reserved NIDs
+	prefix bool // stop at the end of the longest valid prefix
+
 	// Working data
 	id     *URN
 	offset int
 }
 
-func (p *parser) Parse() (*URN, error) {
-	p.id = &URN{}
+func (p *parser) parse() error {
+	*p.id = URN{}
+
+	if err := p.consume(); err != nil {
+		*p.id = URN{}
+
+		return err
+	}
+
+	return nil
+}
 
+func (p *parser) consume() error {
 	if err := p.consumeScheme(); err != nil {
-		return nil, err
+		return err
+	}
+
+	if p.syntax == RFC2141 {
+		return p.consumeLegacy()
 	}
 
 	if err := p.consumeNID(); err != nil {
-		return nil, err
+		return err
+	}
+
+	if err := p.checkNIDKind(); err != nil {
+		return err
 	}
 
 	if err := p.consumeNSS(); err != nil {
-		return nil, err
+		return err
 	}
 
 	if err := p.consumeResolve(); err != nil {
-		return nil, err
+		return err
 	}
 
 	if err := p.consumeQuery(); err != nil {
-		return nil, err
+		return err
 	}
 	if err := p.consumeFragment(); err != nil {
-		return nil, err
+		return err
+	}
+
+	if p.prefix {
+		return nil
 	}
 
 	// Trailing characters, which could be due to failing parsing either
 	// NSS, q-component, or r-component.  Return the appropriate error.
 	if p.offset < p.n {
 		if p.source[p.offset] == '?' {
-			return nil, p.newErr(ErrInvalidIdentifier, "invalid sequence after '?'")
+			return p.newErr(ErrInvalidIdentifier, ComponentUnknown, p.offset, p.n-p.offset,
+				"invalid sequence after '?'")
 		}
 
-		return nil, p.newErr(ErrInvalidNSS, "trailing characters")
+		return p.newErr(ErrInvalidNSS, ComponentNSS, p.offset, p.n-p.offset, "trailing characters")
 	}
 
-	return p.id, nil
+	return nil
 }
 
-func (p *parser) newErr(err error, msg string) error {
-	return &Error{"parse", p.source, err, msg}
+func (p *parser) newErr(err error, c Component, offset, length int, msg string) error {
+	return &Error{
+		Op:        "parse",
+		Data:      p.source,
+		Err:       err,
+		Msg:       msg,
+		Component: c,
+		Offset:    offset,
+		Length:    length,
+	}
+}
+
+// errAt returns an error for the byte at offset i, or for the end of
+// the input if i is past it.
+func (p *parser) errAt(err error, c Component, i int, msg string) error {
+	if i >= p.n {
+		return p.newErr(err, c, p.n, 0, msg)
+	}
+
+	return p.newErr(err, c, i, 1, msg)
 }
 
 func (p *parser) eol() bool {
@@ -75,12 +245,13 @@ func (p *parser) eol() bool {
 
 func (p *parser) consumeScheme() error {
 	if p.n < 4 {
-		return p.newErr(ErrInvalidScheme, "too short")
+		return p.errAt(ErrInvalidScheme, ComponentScheme, p.n, "too short")
 	}
 
 	c1, c2, c3, c4 := p.source[0], p.source[1], p.source[2], p.source[3]
 	if !(c1 == 'u' || c1 == 'U') || !(c2 == 'r' || c2 == 'R') || !(c3 == 'n' || c3 == 'N') || !(c4 == ':') {
-		return p.newErr(ErrInvalidScheme, fmt.Sprintf("unknown scheme %q", p.source[:3]))
+		return p.newErr(ErrInvalidScheme, ComponentScheme, 0, 4,
+			fmt.Sprintf("unknown scheme %q", p.source[:3]))
 	}
 
 	p.id.Scheme = p.source[:3]
@@ -92,8 +263,12 @@ func (p *parser) consumeScheme() error {
 func (p *parser) consumeNID() error {
 	i := p.offset
 
-	if p.eol() || !isAlphaNum(p.source[i]) {
-		return p.newErr(ErrInvalidNID, "unexpected eol")
+	if p.eol() {
+		return p.errAt(ErrInvalidNID, ComponentNID, i, "unexpected eol")
+	}
+
+	if !isAlphaNum(p.source[i]) {
+		return p.errAt(ErrInvalidNID, ComponentNID, i, "invalid initial byte")
 	}
 
 	max := i + MaxLenNID
@@ -109,7 +284,7 @@ func (p *parser) consumeNID() error {
 		}
 
 		if !(isAlphaNum(c) || c == '-') {
-			return p.newErr(ErrInvalidNID, fmt.Sprintf("invalid byte at pos %d", i))
+			return p.errAt(ErrInvalidNID, ComponentNID, i, fmt.Sprintf("invalid byte at pos %d", i))
 		}
 
 		i++
@@ -119,12 +294,16 @@ func (p *parser) consumeNID() error {
 	// already validated that the last one is a pchar or a hyphen, we
 	// just need to check that the last one is not a hyphen.
 	if i >= p.n || !(p.source[i-1] != '-' && p.source[i] == ':') {
-		return p.newErr(ErrInvalidNID, "invalid final byte")
+		if i < p.n && p.source[i-1] == '-' {
+			i--
+		}
+
+		return p.errAt(ErrInvalidNID, ComponentNID, i, "invalid final byte")
 	}
 
 	// NID cannot contain fewer than two pchar.
 	if i-p.offset < 2 {
-		return p.newErr(ErrInvalidNID, "too short")
+		return p.newErr(ErrInvalidNID, ComponentNID, p.offset, i-p.offset, "too short")
 	}
 
 	p.id.NID = p.source[p.offset:i]
@@ -133,9 +312,16 @@ func (p *parser) consumeNID() error {
 	return nil
 }
 
+// isValidNID reports whether s is a syntactically valid NID.
+func isValidNID(s string) bool {
+	p := &parser{source: s + ":", n: len(s) + 1, id: &URN{}}
+
+	return p.consumeNID() == nil && p.eol()
+}
+
 func (p *parser) consumeNSS() error {
 	if p.eol() {
-		return p.newErr(ErrInvalidNSS, "unexpected eol")
+		return p.errAt(ErrInvalidNSS, ComponentNSS, p.offset, "unexpected eol")
 	}
 
 	i := p.offset
@@ -145,7 +331,7 @@ func (p *parser) consumeNSS() error {
 	} else if p.maybePercentEncoded(i) {
 		i += 3
 	} else {
-		return p.newErr(ErrInvalidNSS, "invalid initial byte")
+		return p.errAt(ErrInvalidNSS, ComponentNSS, i, "invalid initial byte")
 	}
 
 	for i < p.n {
@@ -166,7 +352,7 @@ func (p *parser) consumeNSS() error {
 	}
 
 	if i-p.offset < 1 {
-		return p.newErr(ErrInvalidNSS, "too short")
+		return p.newErr(ErrInvalidNSS, ComponentNSS, p.offset, 0, "too short")
 	}
 
 	p.id.NSS = p.source[p.offset:i]
@@ -192,7 +378,7 @@ func (p *parser) consumeResolve() error {
 	i += 2
 
 	if p.eol() {
-		return p.newErr(ErrInvalidResolve, "unexpected eol")
+		return p.errAt(ErrInvalidResolve, ComponentResolve, p.offset, "unexpected eol")
 	}
 
 	// First item must be PChar only.
@@ -201,7 +387,7 @@ func (p *parser) consumeResolve() error {
 	} else if p.maybePercentEncoded(i) {
 		i += 3
 	} else {
-		return p.newErr(ErrInvalidResolve, "invalid first byte")
+		return p.errAt(ErrInvalidResolve, ComponentResolve, i, "invalid first byte")
 	}
 
 	for i < p.n {
@@ -254,7 +440,7 @@ func (p *parser) consumeQuery() error {
 	i += 2
 
 	if p.eol() {
-		return p.newErr(ErrInvalidQuery, "unexpected eol")
+		return p.errAt(ErrInvalidQuery, ComponentQuery, p.offset, "unexpected eol")
 	}
 
 	// First item must be PChar only.
@@ -263,7 +449,7 @@ func (p *parser) consumeQuery() error {
 	} else if p.maybePercentEncoded(i) {
 		i += 3
 	} else {
-		return p.newErr(ErrInvalidQuery, "invalid first byte")
+		return p.errAt(ErrInvalidQuery, ComponentQuery, i, "invalid first byte")
 	}
 
 	for i < p.n {
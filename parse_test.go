@@ -105,6 +105,12 @@ func testEquivalences(t *testing.T, id *urn.URN, norm *urn.URN) {
 	v = urn.Equal(id, id, urn.AllParts, urn.EncodingNormalized)
 	assert.True(t, v, "Equal to itself, full, encoding-normalized")
 
+	v = urn.Equal(id, id, urn.AssignedName, urn.NamespaceNormalized)
+	assert.True(t, v, "Equal to itself, assigned-name, namespace-normalized")
+
+	v = urn.Equal(id, id, urn.AllParts, urn.NamespaceNormalized)
+	assert.True(t, v, "Equal to itself, full, namespace-normalized")
+
 	// Tests against normalized and not-normalized
 	v = urn.Equal(norm, id, urn.AssignedName, urn.CaseNormalized)
 	assert.True(t, v, "Equal to itself, assigned-name, case-normalized")
@@ -117,6 +123,9 @@ func testEquivalences(t *testing.T, id *urn.URN, norm *urn.URN) {
 
 	v = urn.Equal(norm, id, urn.AllParts, urn.EncodingNormalized)
 	assert.True(t, v, "Equal to itself, full, encoding-normalized")
+
+	v = urn.Equal(norm, id, urn.AllParts, urn.NamespaceNormalized)
+	assert.True(t, v, "Equal to itself, full, namespace-normalized")
 }
 
 func testAssignedName(t *testing.T, s string, id *urn.URN) {
@@ -601,3 +610,165 @@ var wikiExamples = []*urnTestCase{
 		URN:   &urn.URN{Scheme: "urn", NID: "eic", NSS: "10X1001A1001A450"},
 	},
 }
+
+func TestParseInto(t *testing.T) {
+	t.Parallel()
+
+	var (
+		u urn.URN
+		p urn.Parser
+	)
+
+	for i, c := range parseCases {
+		msg := fmt.Sprintf("case %d: %q", i+1, c.Input)
+
+		err := urn.ParseInto(&u, c.Input)
+		berr := p.ParseBytes(&u, []byte(c.Input))
+
+		if c.Err != nil {
+			assert.ErrorIs(t, err, c.Err, msg)
+			assert.ErrorIs(t, berr, c.Err, msg)
+			assert.Equal(t, urn.URN{}, u, "zeroed on error: "+msg)
+
+			continue
+		}
+
+		if assert.NoError(t, err, msg) && assert.NoError(t, berr, msg) {
+			assert.Equal(t, *c.URN, u, msg)
+
+			id, err := urn.ParseBytes([]byte(c.Input))
+			if assert.NoError(t, err, msg) {
+				assert.Equal(t, c.URN, id, msg)
+			}
+		}
+	}
+}
+
+func TestParserRetainsBytes(t *testing.T) {
+	t.Parallel()
+
+	var p urn.Parser
+
+	urns := make([]urn.URN, 2000)
+	buf := []byte("urn:example:0000")
+
+	for i := range urns {
+		copy(buf[12:], fmt.Sprintf("%04d", i))
+
+		if !assert.NoError(t, p.ParseBytes(&urns[i], buf)) {
+			return
+		}
+	}
+
+	// Reusing the input buffer must not change parsed URNs.
+	copy(buf, "xxxxxxxxxxxxxxxx")
+
+	for i := range urns {
+		assert.Equal(t, fmt.Sprintf("%04d", i), urns[i].NSS)
+	}
+
+	long := []byte("urn:example:" + strings.Repeat("x", 8192))
+	if assert.NoError(t, p.ParseBytes(&urns[0], long)) {
+		long[12] = 'y'
+		assert.Equal(t, strings.Repeat("x", 8192), urns[0].NSS)
+	}
+}
+
+func TestParseIntoAllocs(t *testing.T) {
+	var (
+		u urn.URN
+		p urn.Parser
+	)
+
+	s := benchmarkURN
+	b := []byte(s)
+
+	// Warm the parser arena.
+	_ = p.ParseBytes(&u, b)
+
+	assert.Zero(t, testing.AllocsPerRun(100, func() {
+		_ = urn.ParseInto(&u, s)
+	}))
+
+	// A new arena block is allocated once every many inputs.
+	assert.Less(t, testing.AllocsPerRun(100, func() {
+		_ = p.ParseBytes(&u, b)
+	}), 0.1)
+}
+
+func TestParserAllocs(t *testing.T) {
+	var u urn.URN
+
+	p := urn.NewParser(urn.StrictNID())
+	legacy := urn.NewParser(urn.Syntax(urn.RFC2141))
+
+	// Parsers with options parse into caller-owned URNs without
+	// allocating.
+	assert.Zero(t, testing.AllocsPerRun(100, func() {
+		_ = p.ParseInto(&u, benchmarkURN)
+	}))
+	assert.Zero(t, testing.AllocsPerRun(100, func() {
+		_ = legacy.ParseInto(&u, "urn:example:a123,z456")
+	}))
+
+	// Byte inputs are copied to the blocks the parser reuses: parsing
+	// 1000 inputs allocates only a few blocks.
+	b := []byte(benchmarkURN)
+
+	assert.LessOrEqual(t, testing.AllocsPerRun(1000, func() {
+		_ = p.ParseBytes(&u, b)
+	}), 0.01)
+}
+
+const benchmarkURN = "urn:example:weather?+CCResolve:cc=uk?=op=map&lat=39.56&lon=-104.85#frag"
+
+func BenchmarkParse(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := urn.Parse(benchmarkURN); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseBytes(b *testing.B) {
+	data := []byte(benchmarkURN)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := urn.ParseBytes(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseInto(b *testing.B) {
+	var u urn.URN
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if err := urn.ParseInto(&u, benchmarkURN); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParserParseBytes(b *testing.B) {
+	var (
+		u urn.URN
+		p urn.Parser
+	)
+
+	data := []byte(benchmarkURN)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if err := p.ParseBytes(&u, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
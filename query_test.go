@@ -54,3 +54,194 @@ var parseQueryCases = []*parseQueryTestCase{
 		},
 	},
 }
+
+func TestQueryValues(t *testing.T) {
+	t.Parallel()
+
+	for i, c := range queryValuesCases {
+		msg := fmt.Sprintf("case %d: %q", i+1, c.Input)
+
+		u, err := urn.Parse(c.Input)
+		if assert.NoError(t, err, msg) {
+			assert.Equal(t, c.Query, u.QueryValues(), msg)
+			assert.Equal(t, c.Resolve, u.ResolveValues(), msg)
+
+			// Re-encoding must result in a valid URN with the same
+			// parameters.
+			u.SetQueryValues(c.Query)
+			u.SetResolveValues(c.Resolve)
+
+			n, err := urn.Parse(u.String())
+			if assert.NoError(t, err, msg) {
+				assert.Equal(t, c.Query, n.QueryValues(), msg)
+				assert.Equal(t, c.Resolve, n.ResolveValues(), msg)
+			}
+		}
+	}
+}
+
+func TestSetQueryValues(t *testing.T) {
+	t.Parallel()
+
+	u, _ := urn.Parse("urn:example:a")
+
+	u.SetResolveValues(urn.Params{{Key: "/k", Value: "?=v"}, {Key: "a b", Value: "c+d"}})
+	u.SetQueryValues(urn.Params{{Key: "?k", Value: "1&2"}, {Key: "a", Value: "=/?#"}})
+
+	assert.Equal(t, "urn:example:a?+%2Fk=%3F%3Dv&a%20b=c+d?=%3Fk=1%262&a=%3D/?%23", u.String())
+
+	n, err := urn.Parse(u.String())
+	if assert.NoError(t, err) {
+		assert.Equal(t, "c+d", n.ResolveValues().Get("a b"))
+		assert.Equal(t, "=/?#", n.QueryValues().Get("a"))
+	}
+
+	u.SetResolveValues(urn.Params{{Key: "a", NoValue: true}, {Key: "b", Value: "1"}})
+	u.SetQueryValues(n.QueryValues())
+	assert.Equal(t, "urn:example:a?+a&b=1?=%3Fk=1%262&a=%3D/?%23", u.String())
+
+	u.SetQueryValues(nil)
+	assert.Equal(t, "", u.Query)
+}
+
+func TestParams(t *testing.T) {
+	t.Parallel()
+
+	p, err := urn.ParseParams("b=1&a=2&b=3&c&&d=")
+	assert.NoError(t, err)
+
+	// A key without '=' encodes back without '='.
+	kv, err := urn.ParseParams("a&b=1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "a&b=1", kv.Encode())
+	}
+
+	assert.Equal(t, urn.Params{
+		{Key: "b", Value: "1"},
+		{Key: "a", Value: "2"},
+		{Key: "b", Value: "3"},
+		{Key: "c", NoValue: true},
+		{Key: "d", Value: ""},
+	}, p)
+	assert.Equal(t, "1", p.Get("b"))
+	assert.Equal(t, []string{"1", "3"}, p.Values("b"))
+	assert.True(t, p.Has("c"))
+	assert.False(t, p.Has("e"))
+	assert.Equal(t, "", p.Get("e"))
+
+	p.Set("b", "x")
+	assert.Equal(t, "b=x&a=2&c&d=", p.Encode())
+
+	p.Set("e", "y")
+	p.Del("a")
+	p.Add("a", "z")
+	assert.Equal(t, "b=x&c&d=&e=y&a=z", p.Encode())
+
+	assert.Equal(t, url.Values{
+		"a": {"z"}, "b": {"x"}, "c": {""}, "d": {""}, "e": {"y"},
+	}, p.URLValues())
+
+	assert.Equal(t,
+		urn.Params{
+			{Key: "a", Value: "1"},
+			{Key: "b", Value: "2"},
+			{Key: "b", Value: "3"},
+		},
+		urn.ParamsFromValues(url.Values{"b": {"2", "3"}, "a": {"1"}}))
+}
+
+func TestParseParams(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Input  string
+		Params urn.Params
+		Offset int
+		Err    bool
+	}{
+		{Input: "", Params: nil},
+		{
+			Input:  "a=1%25&b%20c=%3D",
+			Params: urn.Params{{Key: "a", Value: "1%"}, {Key: "b c", Value: "="}},
+		},
+		{
+			Input:  "a&b=1&c=",
+			Params: urn.Params{{Key: "a", NoValue: true}, {Key: "b", Value: "1"}, {Key: "c"}},
+		},
+		{Input: "a=100%", Offset: 5, Err: true},
+		{Input: "a=%2&b=1", Offset: 2, Err: true},
+		{Input: "%zz=1", Offset: 0, Err: true},
+		{Input: "a=1&b%=2", Offset: 5, Err: true},
+	}
+
+	for i, c := range cases {
+		msg := fmt.Sprintf("case %d: %q", i+1, c.Input)
+
+		p, err := urn.ParseParams(c.Input)
+		if !c.Err {
+			assert.NoError(t, err, msg)
+			assert.Equal(t, c.Params, p, msg)
+
+			continue
+		}
+
+		var uerr *urn.Error
+		if assert.ErrorAs(t, err, &uerr, msg) {
+			assert.ErrorIs(t, err, urn.ErrInvalidEncoding, msg)
+			assert.Equal(t, c.Offset, uerr.Offset, msg)
+			assert.Nil(t, p, msg)
+		}
+	}
+
+	// Components set directly keep invalid escapes.
+	u := &urn.URN{Scheme: "urn", NID: "example", NSS: "a", Query: "a=100%&b=%2"}
+	assert.Equal(t, urn.Params{
+		{Key: "a", Value: "100%"},
+		{Key: "b", Value: "%2"},
+	}, u.QueryValues())
+}
+
+type queryValuesTestCase struct {
+	Input   string
+	Query   urn.Params
+	Resolve urn.Params
+}
+
+var queryValuesCases = []*queryValuesTestCase{
+	{
+		Input: "urn:example:weather?=op=map&lat=39.56&lon=-104.85",
+		Query: urn.Params{
+			{Key: "op", Value: "map"},
+			{Key: "lat", Value: "39.56"},
+			{Key: "lon", Value: "-104.85"},
+		},
+	},
+	{
+		Input:   "urn:example:foo-bar-baz-qux?+CCResolve:cc=uk",
+		Resolve: urn.Params{{Key: "CCResolve:cc", Value: "uk"}},
+	},
+	{
+		// Plus signs are not spaces.
+		Input: "urn:foo:bar?=a=1+2&b=%20",
+		Query: urn.Params{{Key: "a", Value: "1+2"}, {Key: "b", Value: " "}},
+	},
+	{
+		Input:   "urn:foo:bar?+r=%3F%3D?=a=2&a=1%26a=2",
+		Resolve: urn.Params{{Key: "r", Value: "?="}},
+		Query:   urn.Params{{Key: "a", Value: "2"}, {Key: "a", Value: "1&a=2"}},
+	},
+	{
+		Input: "urn:foo:bar?=z=1&y=2&x=3",
+		Query: urn.Params{
+			{Key: "z", Value: "1"},
+			{Key: "y", Value: "2"},
+			{Key: "x", Value: "3"},
+		},
+	},
+	{
+		// Keys without '=' survive re-encoding.
+		Input:   "urn:foo:bar?+a&b=1?=c&d=",
+		Resolve: urn.Params{{Key: "a", NoValue: true}, {Key: "b", Value: "1"}},
+		Query:   urn.Params{{Key: "c", NoValue: true}, {Key: "d"}},
+	},
+}
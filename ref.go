@@ -0,0 +1,174 @@
+package urn
+
+import "fmt"
+
+// A Ref is a compact and immutable representation of a URN.  It holds
+// the text of the URN and the offsets of its components, instead of
+// separate strings for each component.
+//
+// Refs of equal text are equal with ==, so normalized Refs can be used
+// directly as map keys:
+//
+//	seen := map[urn.Ref]bool{}
+//	seen[ref.Normalized()] = true
+//
+// The zero value is an empty Ref with no components.
+type Ref struct {
+	s string
+
+	// Offsets in s.  The NID spans s[nid:nss-1] and the NSS spans
+	// s[nss:nssEnd].  Each of the r-component, q-component and
+	// f-component, if present, follows the end of the previous one.
+	nid        int32
+	nss        int32
+	nssEnd     int32
+	resolveEnd int32
+	queryEnd   int32
+}
+
+// ParseRef parses a raw URN into a Ref.
+func ParseRef(s string) (Ref, error) {
+	var u URN
+
+	if err := ParseInto(&u, s); err != nil {
+		return Ref{}, err
+	}
+
+	return newRef(s, &u), nil
+}
+
+// Ref returns the compact representation of the URN.
+func (u *URN) Ref() Ref {
+	return newRef(u.String(), u)
+}
+
+// newRef returns a Ref of the URN, whose text is s.
+func newRef(s string, u *URN) Ref {
+	r := Ref{s: s}
+
+	r.nid = int32(len(u.Scheme) + 1)
+	r.nss = r.nid + int32(len(u.NID)+1)
+	r.nssEnd = r.nss + int32(len(u.NSS))
+	r.resolveEnd = r.nssEnd
+
+	if u.Resolve != "" {
+		r.resolveEnd += int32(len(u.Resolve) + 2)
+	}
+
+	r.queryEnd = r.resolveEnd
+
+	if u.Query != "" {
+		r.queryEnd += int32(len(u.Query) + 2)
+	}
+
+	return r
+}
+
+// URN returns the URN of the Ref.
+func (r Ref) URN() *URN {
+	return &URN{
+		Scheme:        r.Scheme(),
+		NID:           r.NID(),
+		NSS:           r.NSS(),
+		Resolve:       r.Resolve(),
+		Query:         r.Query(),
+		Fragment:      r.Fragment(),
+		ForceFragment: r.HasFragment() && r.Fragment() == "",
+	}
+}
+
+// IsZero reports whether the Ref is the zero value.
+func (r Ref) IsZero() bool {
+	return r.s == ""
+}
+
+// Scheme returns the URN scheme.
+func (r Ref) Scheme() string {
+	if r.s == "" {
+		return ""
+	}
+
+	return r.s[:r.nid-1]
+}
+
+// NID returns the Namespace Identifier.
+func (r Ref) NID() string {
+	if r.s == "" {
+		return ""
+	}
+
+	return r.s[r.nid : r.nss-1]
+}
+
+// NSS returns the Namespace Specific String.
+func (r Ref) NSS() string {
+	return r.s[r.nss:r.nssEnd]
+}
+
+// Resolve returns the encoded r-component.
+func (r Ref) Resolve() string {
+	if r.resolveEnd == r.nssEnd {
+		return ""
+	}
+
+	return r.s[r.nssEnd+2 : r.resolveEnd]
+}
+
+// Query returns the encoded q-component.
+func (r Ref) Query() string {
+	if r.queryEnd == r.resolveEnd {
+		return ""
+	}
+
+	return r.s[r.resolveEnd+2 : r.queryEnd]
+}
+
+// Fragment returns the encoded f-component.
+func (r Ref) Fragment() string {
+	if !r.HasFragment() {
+		return ""
+	}
+
+	return r.s[r.queryEnd+1:]
+}
+
+// HasFragment reports whether the URN has an f-component, even if empty.
+func (r Ref) HasFragment() bool {
+	return int(r.queryEnd) < len(r.s)
+}
+
+// AssignedName returns scheme:nid:nss.
+func (r Ref) AssignedName() string {
+	return r.s[:r.nssEnd]
+}
+
+// String returns the complete identifier, including components.
+func (r Ref) String() string {
+	return r.s
+}
+
+// Normalized returns the Ref of the case-normalized URN.
+func (r Ref) Normalized() Ref {
+	if r.s == "" {
+		return r
+	}
+
+	return r.URN().Normalized().Ref()
+}
+
+// MarshalText marshals the Ref as text.
+func (r Ref) MarshalText() ([]byte, error) {
+	return []byte(r.s), nil
+}
+
+// UnmarshalText parses URN text input.
+func (r *Ref) UnmarshalText(b []byte) error {
+	n, err := ParseRef(string(b))
+	if err != nil {
+		return fmt.Errorf("urn.Ref.UnmarshalText: %w", err)
+	}
+
+	*r = n
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package urn_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/paulourio/go-urn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRef(t *testing.T) {
+	t.Parallel()
+
+	cases := parseCases
+	cases = append(cases, rfc2141examples...)
+	cases = append(cases, wikiExamples...)
+
+	for i, c := range cases {
+		msg := fmt.Sprintf("case %d: %q", i+1, c.Input)
+
+		ref, err := urn.ParseRef(c.Input)
+		if c.Err != nil {
+			assert.ErrorIs(t, err, c.Err, msg)
+			assert.True(t, ref.IsZero(), msg)
+
+			continue
+		}
+
+		if assert.NoError(t, err, msg) {
+			assert.Equal(t, c.URN.Scheme, ref.Scheme(), msg)
+			assert.Equal(t, c.URN.NID, ref.NID(), msg)
+			assert.Equal(t, c.URN.NSS, ref.NSS(), msg)
+			assert.Equal(t, c.URN.Resolve, ref.Resolve(), msg)
+			assert.Equal(t, c.URN.Query, ref.Query(), msg)
+			assert.Equal(t, c.URN.Fragment, ref.Fragment(), msg)
+			assert.Equal(t, c.URN.AssignedName(), ref.AssignedName(), msg)
+			assert.Equal(t, c.Input, ref.String(), msg)
+			assert.Equal(t, c.URN, ref.URN(), msg)
+			assert.Equal(t, ref, c.URN.Ref(), msg)
+		}
+	}
+}
+
+func TestRefMapKey(t *testing.T) {
+	t.Parallel()
+
+	seen := map[urn.Ref]int{}
+
+	for _, s := range rfc8141 {
+		ref, err := urn.ParseRef(s)
+		if assert.NoError(t, err, s) {
+			seen[ref.Normalized()]++
+		}
+	}
+
+	a, _ := urn.ParseRef("urn:example:a123,z456")
+	b, _ := urn.ParseRef("urn:example:a123%2Cz456")
+
+	// Components are part of the key, so only the first three and
+	// the percent-encoded pair collapse.
+	assert.Equal(t, 3, seen[a])
+	assert.Equal(t, 2, seen[b])
+	assert.Len(t, seen, 11)
+}
+
+func TestRefZero(t *testing.T) {
+	t.Parallel()
+
+	var ref urn.Ref
+
+	assert.True(t, ref.IsZero())
+	assert.Equal(t, "", ref.Scheme())
+	assert.Equal(t, "", ref.NID())
+	assert.Equal(t, "", ref.NSS())
+	assert.Equal(t, "", ref.Resolve())
+	assert.Equal(t, "", ref.Query())
+	assert.Equal(t, "", ref.Fragment())
+	assert.False(t, ref.HasFragment())
+	assert.Equal(t, "", ref.AssignedName())
+	assert.Equal(t, ref, ref.Normalized())
+}
+
+func TestRefMarshaling(t *testing.T) {
+	t.Parallel()
+
+	in := map[string]urn.Ref{}
+
+	in["a"], _ = urn.ParseRef("urn:example:a?+r?=q#")
+
+	d, err := json.Marshal(in)
+	if assert.NoError(t, err) {
+		assert.Equal(t, `{"a":"urn:example:a?+r?=q#"}`, string(d))
+
+		var out map[string]urn.Ref
+
+		if assert.NoError(t, json.Unmarshal(d, &out)) {
+			assert.Equal(t, in, out)
+			assert.True(t, out["a"].HasFragment())
+		}
+	}
+
+	var ref urn.Ref
+
+	assert.Error(t, json.Unmarshal([]byte(`"urn:a:b"`), &ref))
+}
@@ -0,0 +1,329 @@
+package urn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Service is a resolution service from [RFC 2483](urn:ietf:rfc:2483).
+// Services map an identifier (a URN, N; a URL, L; or any URI, I) to
+// locations (L), resources (R), characteristics (C) or URNs (N).  The
+// services ending in "s" return all results, and the others a single
+// one.
+type Service string
+
+const (
+	N2L  Service = "N2L"  // URN to URL
+	N2Ls Service = "N2Ls" // URN to URLs
+	N2R  Service = "N2R"  // URN to resource
+	N2Rs Service = "N2Rs" // URN to resources
+	N2C  Service = "N2C"  // URN to characteristics
+	N2N  Service = "N2N"  // URN to URN
+	N2Ns Service = "N2Ns" // URN to URNs
+	L2R  Service = "L2R"  // URL to resource
+	L2Ns Service = "L2Ns" // URL to URNs
+	L2C  Service = "L2C"  // URL to characteristics
+	I2L  Service = "I2L"  // URI to URL
+	I2Ls Service = "I2Ls" // URI to URLs
+	I2R  Service = "I2R"  // URI to resource
+	I2Rs Service = "I2Rs" // URI to resources
+	I2C  Service = "I2C"  // URI to characteristics
+	I2N  Service = "I2N"  // URI to URN
+	I2Ns Service = "I2Ns" // URI to URNs
+)
+
+var services = map[Service]bool{
+	N2L: true, N2Ls: true, N2R: true, N2Rs: true, N2C: true, N2N: true, N2Ns: true,
+	L2R: true, L2Ns: true, L2C: true,
+	I2L: true, I2Ls: true, I2R: true, I2Rs: true, I2C: true, I2N: true, I2Ns: true,
+}
+
+// ParseService returns the service of the given name, such as "N2L".
+// Names are case-sensitive, as in RFC 2483.
+func ParseService(name string) (Service, bool) {
+	s := Service(name)
+
+	return s, services[s]
+}
+
+// Input returns the kind of identifier taken by the service: 'N', 'L'
+// or 'I'.  It returns 0 if s is not of the form "X2Y".
+func (s Service) Input() byte {
+	if len(s) < 3 {
+		return 0
+	}
+
+	return s[0]
+}
+
+// Plural returns the service returning all results of the same kind as
+// s, such as N2Ls for N2L.  It returns s itself if s is already plural,
+// or if there is no such service.
+func (s Service) Plural() Service {
+	if p := s + "s"; services[p] {
+		return p
+	}
+
+	return s
+}
+
+// A Request is a resolution request.
+type Request struct {
+	Service Service
+
+	// URN is the identifier to resolve, for N2* services.  For I2*
+	// services, it is set if the URI is a URN.
+	URN *URN
+
+	// URI is the identifier to resolve, for L2* and I2* services.
+	URI string
+
+	// Hints are the parameters of the r-component of the URN, which a
+	// resolver may use to select a location or format.
+	//
+	// [RFC 8141 §2.3.1](urn:ietf:rfc:8141#section-2.3.1):
+	//
+	//   The r-component is intended for passing parameters to URN
+	//   resolution services (taken broadly, see [RFC2483]) and
+	//   interpreted by those services.
+	Hints Params
+}
+
+// NewRequest returns a request of the service for the URN, with the
+// hints of its r-component.
+func NewRequest(service Service, u *URN) *Request {
+	return &Request{Service: service, URN: u, URI: u.String(), Hints: u.ResolveValues()}
+}
+
+// A Resource is a resource returned by a resolver.
+type Resource struct {
+	ContentType string
+	Body        []byte
+}
+
+// A Response is the result of a resolution.  Only the field of the kind
+// of result of the service is set.
+type Response struct {
+	Service Service
+
+	Locations       []*url.URL // for *2L and *2Ls
+	Resources       []Resource // for *2R and *2Rs
+	Characteristics Params     // for *2C
+	URNs            []*URN     // for *2N and *2Ns
+}
+
+// Location returns the first location, or nil if there is none.
+func (r *Response) Location() *url.URL {
+	if len(r.Locations) == 0 {
+		return nil
+	}
+
+	return r.Locations[0]
+}
+
+// truncate keeps at most one result if s is a single-result service.
+// Resolvers call it before returning, so that every path answers a
+// single-result service with a single result.
+func (r *Response) truncate(s Service) {
+	if s.Plural() == s {
+		return
+	}
+
+	if len(r.Locations) > 1 {
+		r.Locations = r.Locations[:1]
+	}
+
+	if len(r.Resources) > 1 {
+		r.Resources = r.Resources[:1]
+	}
+
+	if len(r.URNs) > 1 {
+		r.URNs = r.URNs[:1]
+	}
+}
+
+// isEmpty reports whether the response has no results.
+func (r *Response) isEmpty() bool {
+	return len(r.Locations) == 0 && len(r.Resources) == 0 &&
+		len(r.Characteristics) == 0 && len(r.URNs) == 0
+}
+
+// A Resolver implements resolution services.
+//
+// Resolvers return an error wrapping ErrUnsupportedService for the
+// services they do not implement, and ErrNotFound if the identifier
+// has no results.
+type Resolver interface {
+	Resolve(ctx context.Context, req *Request) (*Response, error)
+}
+
+// ResolverFunc is an adapter to use an ordinary function as a Resolver.
+type ResolverFunc func(ctx context.Context, req *Request) (*Response, error)
+
+// Resolve calls f(ctx, req).
+func (f ResolverFunc) Resolve(ctx context.Context, req *Request) (*Response, error) {
+	return f(ctx, req)
+}
+
+// ResolverMux dispatches resolution requests to resolvers by the NID of
+// the URN.  Requests without a URN, or whose NID has no resolver, go to
+// the default resolver, if any.
+//
+// The mux also derives single-result services from the plural ones:
+// when a resolver does not support N2L, the mux asks for N2Ls and keeps
+// the first location.
+//
+// ResolverMux is safe for concurrent use by multiple goroutines.
+type ResolverMux struct {
+	mu  sync.RWMutex
+	m   map[string]Resolver
+	def Resolver
+}
+
+// DefaultResolver is the resolver used by the package-level Resolve.
+var DefaultResolver = NewResolverMux()
+
+// NewResolverMux returns an empty resolver mux.
+func NewResolverMux() *ResolverMux {
+	return &ResolverMux{m: make(map[string]Resolver)}
+}
+
+// Handle registers the resolver for the NID.  It fails if the NID is
+// invalid or if it already has a resolver.
+func (m *ResolverMux) Handle(nid string, r Resolver) error {
+	if !isValidNID(nid) {
+		return &Error{Op: "handle", Data: nid, Err: ErrInvalidNID}
+	}
+
+	key := strings.ToLower(nid)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.m[key]; ok {
+		return &Error{Op: "handle", Data: nid, Err: ErrDuplicateResolver}
+	}
+
+	m.m[key] = r
+
+	return nil
+}
+
+// HandleDefault sets the resolver for requests that no other resolver
+// handles.
+func (m *ResolverMux) HandleDefault(r Resolver) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.def = r
+}
+
+// Lookup returns the resolver for the NID, which is case-insensitive,
+// or the default resolver.
+func (m *ResolverMux) Lookup(nid string) (Resolver, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if r, ok := m.m[strings.ToLower(nid)]; ok {
+		return r, true
+	}
+
+	return m.def, m.def != nil
+}
+
+// Resolve dispatches the request to the resolver of its URN.
+func (m *ResolverMux) Resolve(ctx context.Context, req *Request) (*Response, error) {
+	if !services[req.Service] {
+		return nil, &Error{
+			Op:   "resolve",
+			Data: req.URI,
+			Err:  ErrUnsupportedService,
+			Msg:  fmt.Sprintf("service %q", req.Service),
+		}
+	}
+
+	// Resolvers receive a copy, with the URN of I2* requests.
+	creq := *req
+	req = &creq
+
+	if req.URN == nil && req.Service.Input() != 'L' {
+		u, err := Parse(req.URI)
+		if err == nil {
+			req.URN = u
+			req.Hints = u.ResolveValues()
+		} else if req.Service.Input() == 'N' {
+			return nil, err
+		}
+	}
+
+	var (
+		r  Resolver
+		ok bool
+	)
+
+	if req.URN != nil {
+		r, ok = m.Lookup(req.URN.NID)
+	} else {
+		r, ok = m.Lookup("")
+	}
+
+	if !ok {
+		return nil, m.noResolver(req)
+	}
+
+	resp, err := r.Resolve(ctx, req)
+
+	if plural := req.Service.Plural(); plural != req.Service && errors.Is(err, ErrUnsupportedService) {
+		preq := *req
+		preq.Service = plural
+
+		resp, err = r.Resolve(ctx, &preq)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp == nil || resp.isEmpty() {
+		return nil, &Error{
+			Op:   "resolve",
+			Data: req.URI,
+			Err:  ErrNotFound,
+			Msg:  fmt.Sprintf("service %s", req.Service),
+		}
+	}
+
+	out := *resp
+	out.Service = req.Service
+	out.truncate(req.Service)
+
+	return &out, nil
+}
+
+func (m *ResolverMux) noResolver(req *Request) error {
+	if req.URN == nil {
+		return &Error{Op: "resolve", Data: req.URI, Err: ErrNoResolver}
+	}
+
+	u := req.URN
+
+	return &Error{
+		Op:        "resolve",
+		Data:      u.String(),
+		Err:       ErrNoResolver,
+		Msg:       fmt.Sprintf("NID %q", u.NID),
+		Component: ComponentNID,
+		Offset:    len(u.Scheme) + 1,
+		Length:    len(u.NID),
+	}
+}
+
+// Resolve resolves the URN with the service, using the DefaultResolver.
+// The r-component of the URN is passed to the resolver as hints.
+func Resolve(ctx context.Context, u *URN, service Service) (*Response, error) {
+	return DefaultResolver.Resolve(ctx, NewRequest(service, u))
+}
@@ -0,0 +1,278 @@
+package urn_test
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/paulourio/go-urn"
+	"github.com/stretchr/testify/assert"
+)
+
+// locationsResolver resolves N2Ls and I2Ls to its URLs, recording the
+// last request.
+type locationsResolver struct {
+	urls []string
+	last *urn.Request
+}
+
+func (r *locationsResolver) Resolve(_ context.Context, req *urn.Request) (*urn.Response, error) {
+	r.last = req
+
+	if req.Service != urn.N2Ls && req.Service != urn.I2Ls {
+		return nil, fmt.Errorf("test: %w", urn.ErrUnsupportedService)
+	}
+
+	resp := &urn.Response{}
+
+	for _, s := range r.urls {
+		u, err := url.Parse(s)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Locations = append(resp.Locations, u)
+	}
+
+	return resp, nil
+}
+
+func TestResolverMux(t *testing.T) {
+	t.Parallel()
+
+	isbn := &locationsResolver{urls: []string{"https://a.example/1", "https://b.example/1"}}
+	def := &locationsResolver{urls: []string{"https://default.example/"}}
+	empty := &locationsResolver{}
+
+	m := urn.NewResolverMux()
+	assert.NoError(t, m.Handle("ISBN", isbn))
+	assert.NoError(t, m.Handle("empty", empty))
+	assert.ErrorIs(t, m.Handle("isbn", def), urn.ErrDuplicateResolver)
+	assert.ErrorIs(t, m.Handle("a", def), urn.ErrInvalidNID)
+
+	cases := []struct {
+		Request   *urn.Request
+		Locations []string
+		Err       error
+	}{
+		{
+			Request:   &urn.Request{Service: urn.N2Ls, URI: "urn:isbn:0451450523"},
+			Locations: []string{"https://a.example/1", "https://b.example/1"},
+		},
+		{
+			Request:   &urn.Request{Service: urn.N2L, URI: "urn:isbn:0451450523"},
+			Locations: []string{"https://a.example/1"},
+		},
+		{
+			Request:   &urn.Request{Service: urn.I2L, URI: "URN:ISBN:0451450523"},
+			Locations: []string{"https://a.example/1"},
+		},
+		{Request: &urn.Request{Service: urn.N2L, URI: "urn:other:x"}, Err: urn.ErrNoResolver},
+		{Request: &urn.Request{Service: urn.N2R, URI: "urn:isbn:0451450523"}, Err: urn.ErrUnsupportedService},
+		{Request: &urn.Request{Service: "X2Y", URI: "urn:isbn:0451450523"}, Err: urn.ErrUnsupportedService},
+		{Request: &urn.Request{Service: urn.N2Ls, URI: "urn:empty:x"}, Err: urn.ErrNotFound},
+		{Request: &urn.Request{Service: urn.N2L, URI: "urn:a:b"}, Err: urn.ErrInvalidNID},
+		{Request: &urn.Request{Service: urn.I2L, URI: "https://example.com/"}, Err: urn.ErrNoResolver},
+	}
+
+	for i, c := range cases {
+		msg := fmt.Sprintf("case %d: %s %q", i+1, c.Request.Service, c.Request.URI)
+
+		resp, err := m.Resolve(context.Background(), c.Request)
+		if c.Err != nil {
+			assert.ErrorIs(t, err, c.Err, msg)
+			assert.Nil(t, resp, msg)
+
+			continue
+		}
+
+		if assert.NoError(t, err, msg) {
+			assert.Equal(t, c.Request.Service, resp.Service, msg)
+			assert.Equal(t, c.Locations, locationStrings(resp.Locations), msg)
+			assert.Equal(t, c.Locations[0], resp.Location().String(), msg)
+		}
+
+		// The request of the caller is unchanged.
+		assert.Nil(t, c.Request.URN, msg)
+	}
+
+	m.HandleDefault(def)
+
+	resp, err := m.Resolve(context.Background(), &urn.Request{Service: urn.I2Ls, URI: "https://example.com/"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"https://default.example/"}, locationStrings(resp.Locations))
+		assert.Nil(t, def.last.URN)
+	}
+
+	resp, err = m.Resolve(context.Background(), &urn.Request{Service: urn.N2Ls, URI: "urn:other:x"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"https://default.example/"}, locationStrings(resp.Locations))
+	}
+}
+
+func TestResolverMuxNoResolverError(t *testing.T) {
+	t.Parallel()
+
+	m := urn.NewResolverMux()
+
+	_, err := m.Resolve(context.Background(), &urn.Request{Service: urn.N2L, URI: "urn:other:x"})
+
+	var uerr *urn.Error
+	if assert.ErrorAs(t, err, &uerr) {
+		assert.Equal(t, urn.ComponentNID, uerr.Component)
+		assert.Equal(t, 4, uerr.Offset)
+		assert.Equal(t, 5, uerr.Length)
+		assert.Equal(t,
+			"resolve \"urn:other:x\": no resolver: NID \"other\"\n  urn:other:x\n      ^~~~~",
+			uerr.Pretty())
+	}
+}
+
+func TestResolverMuxErrors(t *testing.T) {
+	t.Parallel()
+
+	m := urn.NewResolverMux()
+	assert.NoError(t, m.Handle("empty", &locationsResolver{}))
+
+	cases := []struct {
+		Request *urn.Request
+		Err     error
+		Message string
+	}{
+		{
+			Request: &urn.Request{Service: "X2Y", URI: "urn:empty:x"},
+			Err:     urn.ErrUnsupportedService,
+			Message: `resolve "urn:empty:x": unsupported service: service "X2Y"`,
+		},
+		{
+			Request: &urn.Request{Service: "", URI: "urn:empty:x"},
+			Err:     urn.ErrUnsupportedService,
+			Message: `resolve "urn:empty:x": unsupported service: service ""`,
+		},
+		{
+			Request: &urn.Request{Service: urn.N2L, URI: "urn:empty:x"},
+			Err:     urn.ErrNotFound,
+			Message: `resolve "urn:empty:x": not found: service N2L`,
+		},
+	}
+
+	for i, c := range cases {
+		msg := fmt.Sprintf("case %d: %s %q", i+1, c.Request.Service, c.Request.URI)
+
+		_, err := m.Resolve(context.Background(), c.Request)
+
+		var uerr *urn.Error
+		if assert.ErrorAs(t, err, &uerr, msg) {
+			assert.ErrorIs(t, err, c.Err, msg)
+			assert.Equal(t, c.Message, uerr.Error(), msg)
+		}
+	}
+}
+
+func TestResolverMuxTruncate(t *testing.T) {
+	t.Parallel()
+
+	// A resolver answering single-result services with several results.
+	r := urn.ResolverFunc(func(_ context.Context, req *urn.Request) (*urn.Response, error) {
+		a, _ := url.Parse("https://a.example/")
+		b, _ := url.Parse("https://b.example/")
+		x, _ := urn.Parse("urn:example:x")
+		y, _ := urn.Parse("urn:example:y")
+
+		return &urn.Response{
+			Service:   req.Service,
+			Locations: []*url.URL{a, b},
+			Resources: []urn.Resource{{Body: []byte("a")}, {Body: []byte("b")}},
+			URNs:      []*urn.URN{x, y},
+		}, nil
+	})
+
+	m := urn.NewResolverMux()
+	assert.NoError(t, m.Handle("example", r))
+
+	cases := []struct {
+		Service urn.Service
+		Results int
+	}{
+		{Service: urn.N2L, Results: 1},
+		{Service: urn.I2L, Results: 1},
+		{Service: urn.N2R, Results: 1},
+		{Service: urn.N2N, Results: 1},
+		{Service: urn.N2Ls, Results: 2},
+		{Service: urn.I2Ls, Results: 2},
+	}
+
+	for i, c := range cases {
+		msg := fmt.Sprintf("case %d: %s", i+1, c.Service)
+
+		resp, err := m.Resolve(context.Background(), &urn.Request{Service: c.Service, URI: "urn:example:a"})
+		if assert.NoError(t, err, msg) {
+			assert.Len(t, resp.Locations, c.Results, msg)
+			assert.Len(t, resp.Resources, c.Results, msg)
+			assert.Len(t, resp.URNs, c.Results, msg)
+		}
+	}
+}
+
+func TestResolveHints(t *testing.T) {
+	t.Parallel()
+
+	r := &locationsResolver{urls: []string{"https://example.com/doc.pdf"}}
+
+	m := urn.NewResolverMux()
+	assert.NoError(t, m.Handle("example", r))
+
+	id, err := urn.Parse("urn:example:doc?+format=pdf&lang=en?=q")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	resp, err := m.Resolve(context.Background(), urn.NewRequest(urn.N2L, id))
+	if assert.NoError(t, err) {
+		assert.Equal(t, urn.N2L, resp.Service)
+		assert.Equal(t, "https://example.com/doc.pdf", resp.Location().String())
+		assert.Equal(t, urn.N2Ls, r.last.Service)
+		assert.Same(t, id, r.last.URN)
+		assert.Equal(t, urn.Params{
+			{Key: "format", Value: "pdf"},
+			{Key: "lang", Value: "en"},
+		}, r.last.Hints)
+	}
+
+	// The package-level Resolve uses the DefaultResolver, which has no
+	// resolver for the NID.
+	_, err = urn.Resolve(context.Background(), id, urn.N2L)
+	assert.ErrorIs(t, err, urn.ErrNoResolver)
+}
+
+func TestService(t *testing.T) {
+	t.Parallel()
+
+	s, ok := urn.ParseService("N2L")
+	assert.True(t, ok)
+	assert.Equal(t, urn.N2L, s)
+	assert.Equal(t, byte('N'), s.Input())
+	assert.Equal(t, urn.N2Ls, s.Plural())
+	assert.Equal(t, urn.N2Ls, urn.N2Ls.Plural())
+	assert.Equal(t, urn.N2C, urn.N2C.Plural())
+	assert.Equal(t, byte('L'), urn.L2R.Input())
+	assert.Equal(t, byte(0), urn.Service("").Input())
+	assert.Equal(t, byte(0), urn.Service("N2").Input())
+
+	_, ok = urn.ParseService("n2l")
+	assert.False(t, ok)
+
+	_, ok = urn.ParseService("")
+	assert.False(t, ok)
+}
+
+func locationStrings(locs []*url.URL) []string {
+	out := make([]string, 0, len(locs))
+
+	for _, u := range locs {
+		out = append(out, u.String())
+	}
+
+	return out
+}
@@ -0,0 +1,195 @@
+package urn
+
+import (
+	"errors"
+	"strings"
+)
+
+// A Match is a URN found in a text.
+type Match struct {
+	Start int  // offset of the first byte of the URN in the text
+	End   int  // offset after the last byte of the URN in the text
+	URN   *URN // parsed URN
+}
+
+// A Scanner finds URNs embedded in free text, such as documents, log
+// lines, HTML and Markdown.
+//
+// Trailing punctuation that is valid in a URN, such as the period ending
+// a sentence or the parenthesis closing a Markdown link, is not taken as
+// part of the URN.  URNs delimited by angle brackets may span multiple
+// lines, as recommended by [RFC 3986 Appendix C](urn:ietf:rfc:3986#appendix-C):
+// white space inside the brackets is removed.
+type Scanner struct {
+	text  string
+	pos   int
+	match Match
+}
+
+// NewScanner returns a scanner of URNs in text.
+func NewScanner(text string) *Scanner {
+	return &Scanner{text: text}
+}
+
+// FindAll returns all URNs found in text, in order.
+func FindAll(text string) []Match {
+	var matches []Match
+
+	s := NewScanner(text)
+	for s.Next() {
+		matches = append(matches, s.Match())
+	}
+
+	return matches
+}
+
+// Next advances the scanner to the next URN, which is then available
+// through Match.  It returns false when there are no more URNs.
+func (s *Scanner) Next() bool {
+	for {
+		i := indexURN(s.text, s.pos)
+		if i < 0 {
+			s.pos = len(s.text)
+			s.match = Match{}
+
+			return false
+		}
+
+		if m, ok := s.matchAt(i); ok {
+			s.match = m
+			s.pos = m.End
+
+			return true
+		}
+
+		s.pos = i + 1
+	}
+}
+
+// Match returns the most recent URN found by Next.
+func (s *Scanner) Match() Match {
+	return s.match
+}
+
+func (s *Scanner) matchAt(i int) (Match, bool) {
+	if i > 0 && s.text[i-1] == '<' {
+		if m, ok := s.matchBracketed(i); ok {
+			return m, true
+		}
+	}
+
+	u := &URN{}
+	limit := len(s.text) - i
+
+	for {
+		n, err := parsePrefix(u, s.text[i:i+limit])
+		if err != nil {
+			// An r-component or q-component without valid content
+			// is not part of the URN.
+			var uerr *Error
+
+			if errors.As(err, &uerr) &&
+				(uerr.Component == ComponentResolve || uerr.Component == ComponentQuery) {
+				limit = uerr.Offset - 2
+
+				continue
+			}
+
+			return Match{}, false
+		}
+
+		t := trimTrailing(s.text[i : i+n])
+		if t == n {
+			return Match{Start: i, End: i + n, URN: u}, true
+		}
+
+		limit = t
+	}
+}
+
+// matchBracketed matches a URN that starts at i right after a '<' and
+// ends at the next '>'.
+func (s *Scanner) matchBracketed(i int) (Match, bool) {
+	end := strings.IndexByte(s.text[i:], '>')
+	if end < 0 {
+		return Match{}, false
+	}
+
+	raw := s.text[i : i+end]
+	if strings.ContainsAny(raw, " \t\r\n") {
+		raw = strings.Join(strings.Fields(raw), "")
+	}
+
+	u, err := Parse(raw)
+	if err != nil {
+		return Match{}, false
+	}
+
+	return Match{Start: i, End: i + end, URN: u}, true
+}
+
+// parsePrefix parses the longest URN at the start of s, returning its
+// length.
+func parsePrefix(dst *URN, s string) (int, error) {
+	p := parser{source: s, n: len(s), id: dst, prefix: true}
+
+	if err := p.parse(); err != nil {
+		return 0, err
+	}
+
+	return p.offset, nil
+}
+
+// indexURN returns the offset of the next "urn:" in s, from offset i,
+// that is not part of a longer word or URI.
+func indexURN(s string, i int) int {
+	for ; i+4 <= len(s); i++ {
+		if s[i]|0x20 != 'u' || s[i+1]|0x20 != 'r' || s[i+2]|0x20 != 'n' || s[i+3] != ':' {
+			continue
+		}
+
+		if i == 0 || !isWordByte(s[i-1]) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func isWordByte(c byte) bool {
+	if isAlphaNum(c) {
+		return true
+	}
+
+	switch c {
+	case '-', '.', '_', '~', '%', '/', ':', '@', '+':
+		return true
+	}
+
+	return false
+}
+
+// trimTrailing returns the length of s without trailing punctuation,
+// keeping closing parentheses that are balanced within s.
+func trimTrailing(s string) int {
+	n := len(s)
+
+	for n > 0 {
+		switch s[n-1] {
+		case '.', ',', ';', ':', '!', '?', '\'', '*':
+			n--
+
+			continue
+		case ')':
+			if strings.Count(s[:n], "(") < strings.Count(s[:n], ")") {
+				n--
+
+				continue
+			}
+		}
+
+		break
+	}
+
+	return n
+}
@@ -0,0 +1,114 @@
+package urn_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/paulourio/go-urn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindAll(t *testing.T) {
+	t.Parallel()
+
+	for i, c := range findAllCases {
+		msg := fmt.Sprintf("case %d: %q", i+1, c.Text)
+
+		matches := urn.FindAll(c.Text)
+		found := make([]string, 0, len(matches))
+
+		for _, m := range matches {
+			found = append(found, c.Text[m.Start:m.End])
+
+			if assert.NotNil(t, m.URN, msg) {
+				assert.Equal(t, c.Text[m.Start:m.End] != m.URN.String(), c.Wrapped, msg)
+			}
+		}
+
+		assert.Equal(t, c.Found, found, msg)
+	}
+}
+
+func TestScanner(t *testing.T) {
+	t.Parallel()
+
+	s := urn.NewScanner("See <urn:ietf:\n    rfc:8141> and urn:isbn:0451450523.")
+
+	if assert.True(t, s.Next()) {
+		m := s.Match()
+		assert.Equal(t, 5, m.Start)
+		assert.Equal(t, 27, m.End)
+		assert.Equal(t, "urn:ietf:rfc:8141", m.URN.String())
+	}
+
+	if assert.True(t, s.Next()) {
+		m := s.Match()
+		assert.Equal(t, 33, m.Start)
+		assert.Equal(t, 52, m.End)
+		assert.Equal(t, "urn:isbn:0451450523", m.URN.String())
+	}
+
+	assert.False(t, s.Next())
+	assert.Equal(t, urn.Match{}, s.Match())
+}
+
+type findAllTestCase struct {
+	Text    string
+	Found   []string
+	Wrapped bool // whether white space was removed from matches
+}
+
+var findAllCases = []*findAllTestCase{
+	{Text: "", Found: []string{}},
+	{Text: "no identifiers here: urn", Found: []string{}},
+	{Text: "urn:ietf:rfc:8141", Found: []string{"urn:ietf:rfc:8141"}},
+	{
+		Text:  "[IETF's RFC 8141](urn:ietf:rfc:8141).",
+		Found: []string{"urn:ietf:rfc:8141"},
+	},
+	{
+		Text:  "[RFC 3986 §6.2.1](urn:ietf:rfc:3986#section-6.2.1):",
+		Found: []string{"urn:ietf:rfc:3986#section-6.2.1"},
+	},
+	{
+		Text:  "Values: urn:a1:(x), URN:B2:y; urn:c3:z!",
+		Found: []string{"urn:a1:(x)", "URN:B2:y", "urn:c3:z"},
+	},
+	{
+		Text:  `<a href="urn:isbn:0451450523">book</a>`,
+		Found: []string{"urn:isbn:0451450523"},
+	},
+	{
+		Text:  "Is it urn:example:foo?",
+		Found: []string{"urn:example:foo"},
+	},
+	{
+		Text:  "Query urn:example:weather?=op=map&lat=39.56 now",
+		Found: []string{"urn:example:weather?=op=map&lat=39.56"},
+	},
+	{
+		Text:  "Empty urn:example:foo?= component and urn:example:bar?+",
+		Found: []string{"urn:example:foo", "urn:example:bar"},
+	},
+	{
+		Text:  "Not a match: xurn:ab:c, http://zoobank.org/urn:lsid:a:b or urn:a:b",
+		Found: []string{},
+	},
+	{
+		Text:  "Nested urn:urn:ex:a",
+		Found: []string{"urn:urn:ex:a"},
+	},
+	{
+		Text:    "<urn:ietf:\n\trfc:8141>",
+		Found:   []string{"urn:ietf:\n\trfc:8141"},
+		Wrapped: true,
+	},
+	{
+		Text:  "<urn:ietf:rfc:8141",
+		Found: []string{"urn:ietf:rfc:8141"},
+	},
+	{
+		Text:  "urn:aa:1 urn:bb:2\nurn:cc:3",
+		Found: []string{"urn:aa:1", "urn:bb:2", "urn:cc:3"},
+	},
+}
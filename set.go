@@ -0,0 +1,237 @@
+package urn
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// A Set is a set of URNs, where URNs are the same element when they are
+// equal under Equal for the set's comparison part and method.  For
+// example, with CaseNormalized, adding "URN:IETF:rfc:8141" and
+// "urn:ietf:rfc:8141" results in a single element.
+//
+// The zero value is an empty set comparing AssignedName with Simple.  A
+// nil *Set is an empty set too, for reading and as an operand of Union,
+// Intersection and Difference.  A Set is not safe for concurrent use by
+// multiple goroutines.
+type Set struct {
+	part   ComparisonPart
+	method ComparisonMethod
+	m      map[string]*URN // by Key
+}
+
+// NewSet returns a set comparing URNs by part and method, with the given
+// URNs.
+func NewSet(part ComparisonPart, method ComparisonMethod, ids ...*URN) *Set {
+	s := &Set{part: part, method: method, m: make(map[string]*URN, len(ids))}
+
+	for _, u := range ids {
+		s.Add(u)
+	}
+
+	return s
+}
+
+// Part returns the comparison part of the set.
+func (s *Set) Part() ComparisonPart {
+	if s == nil {
+		return AssignedName
+	}
+
+	return s.part
+}
+
+// Method returns the comparison method of the set.
+func (s *Set) Method() ComparisonMethod {
+	if s == nil {
+		return Simple
+	}
+
+	return s.method
+}
+
+// Len returns the number of elements in the set.
+func (s *Set) Len() int {
+	return len(s.elements())
+}
+
+// Add adds the URN to the set, and reports whether it was added.  If
+// the set already has an equal URN, the set is unchanged, keeping the
+// URN added first.  A nil URN is not added.
+func (s *Set) Add(u *URN) bool {
+	if u == nil {
+		return false
+	}
+
+	k := s.key(u)
+
+	if _, ok := s.m[k]; ok {
+		return false
+	}
+
+	if s.m == nil {
+		s.m = make(map[string]*URN)
+	}
+
+	s.m[k] = u
+
+	return true
+}
+
+// Remove removes the element equal to the URN, and reports whether it
+// was in the set.
+func (s *Set) Remove(u *URN) bool {
+	k := s.key(u)
+
+	if _, ok := s.elements()[k]; !ok {
+		return false
+	}
+
+	delete(s.m, k)
+
+	return true
+}
+
+// Contains reports whether the set has an element equal to the URN.
+func (s *Set) Contains(u *URN) bool {
+	_, ok := s.elements()[s.key(u)]
+
+	return ok
+}
+
+// Get returns the element equal to the URN, as it was added.
+func (s *Set) Get(u *URN) (*URN, bool) {
+	e, ok := s.elements()[s.key(u)]
+
+	return e, ok
+}
+
+// URNs returns the elements of the set in canonical order, that is,
+// sorted as by Compare.
+func (s *Set) URNs() []*URN {
+	m := s.elements()
+	ids := make([]*URN, 0, len(m))
+
+	for _, k := range sortedKeys(m) {
+		ids = append(ids, m[k])
+	}
+
+	return ids
+}
+
+// Range calls fn for each element of the set in canonical order, until
+// fn returns false.
+func (s *Set) Range(fn func(u *URN) bool) {
+	m := s.elements()
+
+	for _, k := range sortedKeys(m) {
+		if !fn(m[k]) {
+			return
+		}
+	}
+}
+
+// Union returns a new set with the elements of s and o, with the
+// comparison part and method of s.
+func (s *Set) Union(o *Set) *Set {
+	r := NewSet(s.Part(), s.Method())
+
+	for _, u := range s.elements() {
+		r.Add(u)
+	}
+
+	for _, u := range o.URNs() {
+		r.Add(u)
+	}
+
+	return r
+}
+
+// Intersection returns a new set with the elements of s that are equal
+// to elements of o, with the comparison part and method of s.
+func (s *Set) Intersection(o *Set) *Set {
+	r := NewSet(s.Part(), s.Method())
+	in := NewSet(s.Part(), s.Method(), o.URNs()...)
+
+	for k, u := range s.elements() {
+		if _, ok := in.m[k]; ok {
+			r.m[k] = u
+		}
+	}
+
+	return r
+}
+
+// Difference returns a new set with the elements of s that are not
+// equal to elements of o, with the comparison part and method of s.
+func (s *Set) Difference(o *Set) *Set {
+	r := NewSet(s.Part(), s.Method())
+	out := NewSet(s.Part(), s.Method(), o.URNs()...)
+
+	for k, u := range s.elements() {
+		if _, ok := out.m[k]; !ok {
+			r.m[k] = u
+		}
+	}
+
+	return r
+}
+
+// MarshalJSON marshals the set as an array of URN strings, in canonical
+// order.
+func (s *Set) MarshalJSON() ([]byte, error) {
+	m := s.elements()
+	ids := make([]string, 0, len(m))
+
+	for _, k := range sortedKeys(m) {
+		ids = append(ids, m[k].String())
+	}
+
+	return json.Marshal(ids)
+}
+
+// UnmarshalJSON adds the URNs of a JSON array of strings to the set.
+func (s *Set) UnmarshalJSON(b []byte) error {
+	var ids []string
+
+	if err := json.Unmarshal(b, &ids); err != nil {
+		return fmt.Errorf("urn.Set.UnmarshalJSON: %w", err)
+	}
+
+	for _, raw := range ids {
+		u, err := Parse(raw)
+		if err != nil {
+			return fmt.Errorf("urn.Set.UnmarshalJSON: %w", err)
+		}
+
+		s.Add(u)
+	}
+
+	return nil
+}
+
+func (s *Set) key(u *URN) string {
+	return Key(u, s.Part(), s.Method())
+}
+
+// elements returns the elements by key, or nil if s is nil.
+func (s *Set) elements() map[string]*URN {
+	if s == nil {
+		return nil
+	}
+
+	return s.m
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
@@ -0,0 +1,207 @@
+package urn_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/paulourio/go-urn"
+	"github.com/stretchr/testify/assert"
+)
+
+func parseAll(t *testing.T, inputs ...string) []*urn.URN {
+	t.Helper()
+
+	ids := make([]*urn.URN, 0, len(inputs))
+
+	for _, s := range inputs {
+		u, err := urn.Parse(s)
+		if err != nil {
+			t.Fatalf("parse %q: %v", s, err)
+		}
+
+		ids = append(ids, u)
+	}
+
+	return ids
+}
+
+func urnStrings(ids []*urn.URN) []string {
+	out := make([]string, 0, len(ids))
+
+	for _, u := range ids {
+		out = append(out, u.String())
+	}
+
+	return out
+}
+
+func TestSet(t *testing.T) {
+	t.Parallel()
+
+	inputs := []string{
+		"URN:IETF:rfc:8141",
+		"urn:ietf:rfc:8141",
+		"urn:ietf:rfc:8141?=q",
+		"urn:ietf:rfc:%38141",
+		"urn:example:b",
+	}
+
+	cases := []struct {
+		Part   urn.ComparisonPart
+		Method urn.ComparisonMethod
+		URNs   []string
+	}{
+		{urn.AssignedName, urn.Simple, []string{
+			"URN:IETF:rfc:8141", "urn:example:b", "urn:ietf:rfc:%38141", "urn:ietf:rfc:8141",
+		}},
+		{urn.AssignedName, urn.CaseNormalized, []string{
+			"urn:example:b", "urn:ietf:rfc:%38141", "URN:IETF:rfc:8141",
+		}},
+		{urn.AssignedName, urn.EncodingNormalized, []string{
+			"urn:example:b", "URN:IETF:rfc:8141",
+		}},
+		{urn.AllParts, urn.CaseNormalized, []string{
+			"urn:example:b", "urn:ietf:rfc:%38141", "URN:IETF:rfc:8141", "urn:ietf:rfc:8141?=q",
+		}},
+	}
+
+	for i, c := range cases {
+		msg := fmt.Sprintf("case %d: %d/%d", i+1, c.Part, c.Method)
+
+		s := urn.NewSet(c.Part, c.Method, parseAll(t, inputs...)...)
+
+		assert.Equal(t, c.Part, s.Part(), msg)
+		assert.Equal(t, c.Method, s.Method(), msg)
+		assert.Equal(t, len(c.URNs), s.Len(), msg)
+		assert.Equal(t, c.URNs, urnStrings(s.URNs()), msg)
+
+		var ranged []*urn.URN
+
+		s.Range(func(u *urn.URN) bool {
+			ranged = append(ranged, u)
+
+			return len(ranged) < 2
+		})
+		assert.Equal(t, c.URNs[:2], urnStrings(ranged), msg)
+
+		for _, u := range parseAll(t, inputs...) {
+			assert.True(t, s.Contains(u), msg)
+			assert.False(t, s.Add(u), msg)
+		}
+	}
+}
+
+func TestSetAddRemove(t *testing.T) {
+	t.Parallel()
+
+	var s urn.Set
+
+	ids := parseAll(t, "URN:Example:A", "urn:example:A", "urn:example:a")
+
+	assert.True(t, s.Add(ids[0]))
+	assert.False(t, s.Add(ids[0]))
+	assert.True(t, s.Add(ids[1]))
+	assert.Equal(t, 2, s.Len())
+
+	s = *urn.NewSet(urn.AssignedName, urn.CaseNormalized)
+
+	assert.True(t, s.Add(ids[0]))
+	assert.False(t, s.Add(ids[1]))
+	assert.True(t, s.Add(ids[2]))
+
+	got, ok := s.Get(ids[1])
+	assert.True(t, ok)
+	assert.Same(t, ids[0], got)
+
+	assert.True(t, s.Remove(ids[1]))
+	assert.False(t, s.Remove(ids[0]))
+	assert.False(t, s.Contains(ids[0]))
+	assert.Equal(t, []string{"urn:example:a"}, urnStrings(s.URNs()))
+}
+
+func TestSetOperations(t *testing.T) {
+	t.Parallel()
+
+	a := urn.NewSet(urn.AssignedName, urn.CaseNormalized,
+		parseAll(t, "urn:example:a", "urn:example:b", "urn:example:c")...)
+	b := urn.NewSet(urn.AllParts, urn.Simple,
+		parseAll(t, "URN:EXAMPLE:b", "urn:example:c?=q", "urn:example:d")...)
+
+	union := a.Union(b)
+	assert.Equal(t, urn.CaseNormalized, union.Method())
+	assert.Equal(t,
+		[]string{"urn:example:a", "urn:example:b", "urn:example:c", "urn:example:d"},
+		urnStrings(union.URNs()))
+
+	assert.Equal(t,
+		[]string{"urn:example:b", "urn:example:c"},
+		urnStrings(a.Intersection(b).URNs()))
+
+	assert.Equal(t,
+		[]string{"urn:example:a"},
+		urnStrings(a.Difference(b).URNs()))
+
+	// Under the parameters of b, no element of a is in b.
+	assert.Equal(t, 3, b.Difference(a).Len())
+	assert.Equal(t, 0, b.Intersection(a).Len())
+
+	// Operands are unchanged.
+	assert.Equal(t, 3, a.Len())
+	assert.Equal(t, 3, b.Len())
+}
+
+func TestSetNil(t *testing.T) {
+	t.Parallel()
+
+	var n *urn.Set
+
+	a := urn.NewSet(urn.AssignedName, urn.CaseNormalized,
+		parseAll(t, "urn:example:a", "URN:EXAMPLE:b")...)
+	u := a.URNs()[0]
+
+	// A nil set is empty.
+	assert.Equal(t, 0, n.Len())
+	assert.Empty(t, n.URNs())
+	assert.False(t, n.Contains(u))
+	assert.False(t, n.Remove(u))
+	n.Range(func(*urn.URN) bool { t.Error("unexpected element"); return true })
+
+	b, err := n.MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, `[]`, string(b))
+
+	assert.Equal(t, []string{"urn:example:a", "URN:EXAMPLE:b"}, urnStrings(a.Union(n).URNs()))
+	assert.Equal(t, 0, a.Intersection(n).Len())
+	assert.Equal(t, 2, a.Difference(n).Len())
+
+	assert.Equal(t, 2, n.Union(a).Len())
+	assert.Equal(t, urn.Simple, n.Union(a).Method())
+	assert.Equal(t, 0, n.Intersection(a).Len())
+	assert.Equal(t, 0, n.Difference(a).Len())
+
+	// Nil URNs are not added.
+	assert.False(t, a.Add(nil))
+	assert.False(t, a.Contains(nil))
+	assert.Len(t, urn.NewSet(urn.AllParts, urn.Simple, nil, u, nil).URNs(), 1)
+
+	b, err = json.Marshal(a)
+	assert.NoError(t, err)
+	assert.Equal(t, `["urn:example:a","URN:EXAMPLE:b"]`, string(b))
+}
+
+func TestSetJSON(t *testing.T) {
+	t.Parallel()
+
+	s := urn.NewSet(urn.AssignedName, urn.CaseNormalized)
+
+	err := json.Unmarshal([]byte(`["urn:example:b","URN:EXAMPLE:a","urn:example:a","urn:example:b?=q"]`), s)
+	if assert.NoError(t, err) {
+		b, err := json.Marshal(s)
+		assert.NoError(t, err)
+		assert.Equal(t, `["URN:EXAMPLE:a","urn:example:b"]`, string(b))
+	}
+
+	assert.Error(t, json.Unmarshal([]byte(`["urn:a:b"]`), s))
+	assert.Error(t, json.Unmarshal([]byte(`{}`), s))
+}
@@ -0,0 +1,207 @@
+package urn
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Scan implements the database/sql.Scanner interface.  It parses URNs
+// stored as text.  A NULL value is an error; use NullURN for nullable
+// columns.
+//
+// The URN is kept as stored, without normalization.  To normalize URNs
+// read and written, use NormalizedURN, or NullURN for nullable columns.
+func (u *URN) Scan(src interface{}) error {
+	var s string
+
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	case nil:
+		return fmt.Errorf("urn.Scan: cannot scan NULL into URN")
+	default:
+		return fmt.Errorf("urn.Scan: cannot scan %T into URN", src)
+	}
+
+	n, err := Parse(s)
+	if err != nil {
+		return fmt.Errorf("urn.Scan: %w", err)
+	}
+
+	*u = *n
+	return nil
+}
+
+// Value implements the database/sql/driver.Valuer interface.  The URN
+// is stored as text, as is; see NormalizedURN for normalizing it.
+func (u *URN) Value() (driver.Value, error) {
+	if u == nil {
+		return nil, nil
+	}
+
+	return u.String(), nil
+}
+
+// NormalizedURN represents a URN that is normalized when read and
+// written, for NOT NULL columns.
+//
+// NormalizedURN implements the database/sql.Scanner and driver.Valuer
+// interfaces, and flag.Value.  A NULL value is an error when scanned.
+//
+//	id := urn.NormalizedURN{Method: urn.CaseNormalized}
+//	err := row.Scan(&id)
+type NormalizedURN struct {
+	URN URN
+
+	// Method is the normalization applied to URNs read and written.
+	// The zero value, Simple, keeps URNs as they are.
+	Method ComparisonMethod
+}
+
+// Scan implements the database/sql.Scanner interface.
+func (n *NormalizedURN) Scan(src interface{}) error {
+	var u URN
+
+	if err := u.Scan(src); err != nil {
+		return err
+	}
+
+	n.URN = *u.NormalizedBy(n.Method)
+
+	return nil
+}
+
+// Value implements the database/sql/driver.Valuer interface.
+func (n NormalizedURN) Value() (driver.Value, error) {
+	return n.URN.NormalizedBy(n.Method).String(), nil
+}
+
+// Set implements the flag.Value interface.
+func (n *NormalizedURN) Set(s string) error {
+	u, err := Parse(s)
+	if err != nil {
+		return err
+	}
+
+	n.URN = *u.NormalizedBy(n.Method)
+
+	return nil
+}
+
+// String returns the normalized URN.
+func (n *NormalizedURN) String() string {
+	return n.URN.NormalizedBy(n.Method).String()
+}
+
+// NullURN represents a URN that may be null, and that is normalized
+// when read and written.
+//
+// NullURN implements the database/sql.Scanner and driver.Valuer
+// interfaces, so it can be used as a scan destination and as a query
+// argument.  It also implements flag.Value, for optional flags, and
+// JSON marshaling, where null is an invalid NullURN.
+//
+// URNs written and read with the same Method compare equal as strings,
+// whichever path produced them:
+//
+//	id := urn.NullURN{Method: urn.CaseNormalized}
+//	err := row.Scan(&id)
+type NullURN struct {
+	URN   URN
+	Valid bool // Valid is true if URN is not NULL
+
+	// Method is the normalization applied to URNs read and written.
+	// The zero value, Simple, keeps URNs as they are.
+	Method ComparisonMethod
+}
+
+// Scan implements the database/sql.Scanner interface.
+func (n *NullURN) Scan(src interface{}) error {
+	if src == nil {
+		n.URN, n.Valid = URN{}, false
+
+		return nil
+	}
+
+	var u URN
+
+	if err := u.Scan(src); err != nil {
+		return err
+	}
+
+	n.set(&u)
+
+	return nil
+}
+
+// Value implements the database/sql/driver.Valuer interface.
+func (n NullURN) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	return n.URN.NormalizedBy(n.Method).String(), nil
+}
+
+// Set implements the flag.Value interface.
+func (n *NullURN) Set(s string) error {
+	u, err := Parse(s)
+	if err != nil {
+		return err
+	}
+
+	n.set(u)
+
+	return nil
+}
+
+// String returns the normalized URN, or an empty string if it is not
+// valid.
+func (n *NullURN) String() string {
+	if !n.Valid {
+		return ""
+	}
+
+	return n.URN.NormalizedBy(n.Method).String()
+}
+
+// MarshalJSON marshals the normalized URN as a JSON string, or null if
+// it is not valid.
+func (n NullURN) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(n.URN.NormalizedBy(n.Method).String())
+}
+
+// UnmarshalJSON parses a JSON string or null.
+func (n *NullURN) UnmarshalJSON(b []byte) error {
+	var s *string
+
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("urn.NullURN.UnmarshalJSON: %w", err)
+	}
+
+	if s == nil {
+		n.URN, n.Valid = URN{}, false
+
+		return nil
+	}
+
+	u, err := Parse(*s)
+	if err != nil {
+		return fmt.Errorf("urn.NullURN.UnmarshalJSON: %w", err)
+	}
+
+	n.set(u)
+
+	return nil
+}
+
+func (n *NullURN) set(u *URN) {
+	n.URN, n.Valid = *u.NormalizedBy(n.Method), true
+}
@@ -0,0 +1,260 @@
+package urn_test
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/gob"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/paulourio/go-urn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScan(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Src interface{}
+		URN string
+		Err bool
+	}{
+		{Src: "urn:example:a", URN: "urn:example:a"},
+		{Src: []byte("URN:Example:a%2f?=q"), URN: "URN:Example:a%2f?=q"},
+		{Src: "urn:a:b", Err: true},
+		{Src: nil, Err: true},
+		{Src: 42, Err: true},
+	}
+
+	for i, c := range cases {
+		msg := fmt.Sprintf("case %d: %#v", i+1, c.Src)
+
+		var u urn.URN
+
+		err := u.Scan(c.Src)
+		if c.Err {
+			assert.Error(t, err, msg)
+
+			continue
+		}
+
+		if assert.NoError(t, err, msg) {
+			assert.Equal(t, c.URN, u.String(), msg)
+
+			v, err := u.Value()
+			assert.NoError(t, err, msg)
+			assert.Equal(t, driver.Value(c.URN), v, msg)
+		}
+	}
+
+	var u *urn.URN
+
+	v, err := u.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+}
+
+func TestNormalizedURN(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Src    interface{}
+		Method urn.ComparisonMethod
+		Value  driver.Value
+		Err    bool
+	}{
+		{Src: "URN:Example:a%2f", Method: urn.Simple, Value: "URN:Example:a%2f"},
+		{Src: "URN:Example:a%2f", Method: urn.CaseNormalized, Value: "urn:example:a%2F"},
+		{Src: []byte("urn:EXAMPLE:%61"), Method: urn.EncodingNormalized, Value: "urn:example:a"},
+		{Src: "urn:ISBN:0-395-36341-1", Method: urn.NamespaceNormalized, Value: "urn:isbn:9780395363416"},
+		{Src: nil, Method: urn.CaseNormalized, Err: true},
+		{Src: "urn:a:b", Method: urn.CaseNormalized, Err: true},
+	}
+
+	for i, c := range cases {
+		msg := fmt.Sprintf("case %d: %#v", i+1, c.Src)
+
+		n := urn.NormalizedURN{Method: c.Method}
+
+		err := n.Scan(c.Src)
+		if c.Err {
+			assert.Error(t, err, msg)
+
+			continue
+		}
+
+		if !assert.NoError(t, err, msg) {
+			continue
+		}
+
+		v, err := n.Value()
+		assert.NoError(t, err, msg)
+		assert.Equal(t, c.Value, v, msg)
+		assert.Equal(t, c.Value, n.String(), msg)
+
+		// The stored value reads back as the same URN, and URNs written
+		// through another path compare equal.
+		r := urn.NormalizedURN{Method: c.Method}
+		assert.NoError(t, r.Scan(v), msg)
+		assert.Equal(t, n, r, msg)
+
+		w := urn.NormalizedURN{Method: c.Method}
+		assert.NoError(t, w.Set(c.Value.(string)), msg)
+		assert.Equal(t, n, w, msg)
+	}
+
+	// A plain URN is stored as is.
+	var u urn.URN
+
+	if assert.NoError(t, u.Scan("URN:Example:a%2f")) {
+		v, err := u.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, driver.Value("URN:Example:a%2f"), v)
+	}
+
+	var f flag.Value = &urn.NormalizedURN{}
+	assert.Error(t, f.Set("urn:a:b"))
+}
+
+func TestNullURN(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Src    interface{}
+		Method urn.ComparisonMethod
+		Valid  bool
+		Value  driver.Value
+	}{
+		{Src: nil, Method: urn.CaseNormalized},
+		{Src: "URN:Example:a%2f", Method: urn.Simple, Valid: true, Value: "URN:Example:a%2f"},
+		{Src: "URN:Example:a%2f", Method: urn.CaseNormalized, Valid: true, Value: "urn:example:a%2F"},
+		{Src: []byte("urn:example:%61"), Method: urn.EncodingNormalized, Valid: true, Value: "urn:example:a"},
+		{Src: "urn:ISBN:0-395-36341-1", Method: urn.NamespaceNormalized, Valid: true, Value: "urn:isbn:9780395363416"},
+	}
+
+	for i, c := range cases {
+		msg := fmt.Sprintf("case %d: %#v", i+1, c.Src)
+
+		n := urn.NullURN{Method: c.Method, Valid: true}
+
+		if !assert.NoError(t, n.Scan(c.Src), msg) {
+			continue
+		}
+
+		assert.Equal(t, c.Valid, n.Valid, msg)
+
+		v, err := n.Value()
+		assert.NoError(t, err, msg)
+		assert.Equal(t, c.Value, v, msg)
+
+		// URNs written through another path compare equal.
+		if c.Valid {
+			w := urn.NullURN{Method: c.Method}
+			assert.NoError(t, w.Set(c.Value.(string)), msg)
+			assert.Equal(t, n, w, msg)
+		}
+	}
+
+	var n urn.NullURN
+	assert.Error(t, n.Scan("urn:a:b"))
+	assert.Error(t, n.Scan(1.5))
+	assert.False(t, n.Valid)
+}
+
+func TestNullURNJSON(t *testing.T) {
+	t.Parallel()
+
+	type record struct {
+		ID urn.NullURN `json:"id"`
+	}
+
+	cases := []struct {
+		Input  string
+		Output string
+		Err    bool
+	}{
+		{Input: `{"id":null}`, Output: `{"id":null}`},
+		{Input: `{}`, Output: `{"id":null}`},
+		{Input: `{"id":"URN:Example:a%2f"}`, Output: `{"id":"urn:example:a%2F"}`},
+		{Input: `{"id":"urn:example:a&b"}`, Output: `{"id":"urn:example:a\u0026b"}`},
+		{Input: `{"id":"urn:a:b"}`, Err: true},
+		{Input: `{"id":1}`, Err: true},
+	}
+
+	for i, c := range cases {
+		msg := fmt.Sprintf("case %d: %q", i+1, c.Input)
+
+		r := record{ID: urn.NullURN{Method: urn.CaseNormalized}}
+
+		err := json.Unmarshal([]byte(c.Input), &r)
+		if c.Err {
+			assert.Error(t, err, msg)
+
+			continue
+		}
+
+		if assert.NoError(t, err, msg) {
+			b, err := json.Marshal(r)
+			assert.NoError(t, err, msg)
+			assert.Equal(t, c.Output, string(b), msg)
+		}
+	}
+}
+
+func TestURNFlag(t *testing.T) {
+	t.Parallel()
+
+	var (
+		id  urn.URN
+		opt = urn.NullURN{Method: urn.CaseNormalized}
+	)
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&id, "id", "")
+	fs.Var(&opt, "opt", "")
+
+	assert.NoError(t, fs.Parse([]string{"-id", "urn:example:a"}))
+	assert.Equal(t, "urn:example:a", id.String())
+	assert.False(t, opt.Valid)
+
+	assert.NoError(t, fs.Parse([]string{"-opt", "URN:EXAMPLE:b"}))
+	assert.True(t, opt.Valid)
+	assert.Equal(t, "urn:example:b", opt.String())
+
+	assert.Error(t, fs.Parse([]string{"-id", "urn:a:b"}))
+}
+
+func TestURNGob(t *testing.T) {
+	t.Parallel()
+
+	type record struct {
+		ID  urn.URN
+		Ptr *urn.URN
+	}
+
+	id, _ := urn.Parse("urn:example:a?+r?=q#f")
+	ptr, _ := urn.Parse("URN:Example:b")
+	in := record{ID: *id, Ptr: ptr}
+
+	var buf bytes.Buffer
+
+	assert.NoError(t, gob.NewEncoder(&buf).Encode(&in))
+
+	var out record
+
+	if assert.NoError(t, gob.NewDecoder(&buf).Decode(&out)) {
+		assert.Equal(t, in, out)
+	}
+
+	var u urn.URN
+
+	b, err := in.Ptr.MarshalBinary()
+	assert.NoError(t, err)
+	assert.NoError(t, u.UnmarshalBinary(b))
+	assert.Equal(t, in.Ptr, &u)
+	assert.Error(t, u.UnmarshalBinary([]byte("urn:a:b")))
+}
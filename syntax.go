@@ -0,0 +1,232 @@
+package urn
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SyntaxVersion selects the grammar used to parse URNs.
+type SyntaxVersion int
+
+const (
+	// RFC8141 is the current URN syntax, with optional r-component,
+	// q-component and f-component.
+	RFC8141 SyntaxVersion = iota
+	// RFC2141 is the original URN syntax, obsoleted by RFC 8141.
+	//
+	// Under RFC 2141 there are no components after the NSS: the
+	// characters "/", "?" and "#" were reserved, and are taken as part
+	// of the NSS.  The NSS cannot contain "&" or "~", and the NID may
+	// have a single character or end with a hyphen, but cannot be
+	// "urn".
+	//
+	// [RFC 2141 §2.2](urn:ietf:rfc:2141#section-2.2):
+	//
+	//   RFC 1630 reserves the characters "/", "?", and "#" for particular
+	//   purposes. The URN-WG has not yet debated the applicability and
+	//   precise semantics of those purposes as applied to URNs. Therefore,
+	//   these characters are RESERVED for future developments.
+	RFC2141
+)
+
+func (v SyntaxVersion) String() string {
+	switch v {
+	case RFC8141:
+		return "RFC 8141"
+	case RFC2141:
+		return "RFC 2141"
+	}
+
+	return fmt.Sprintf("SyntaxVersion(%d)", int(v))
+}
+
+// Syntax sets the grammar used by a parser.  The default is RFC8141.
+func Syntax(v SyntaxVersion) ParseOption {
+	return func(p *Parser) {
+		p.syntax = v
+	}
+}
+
+// Features is a set of RFC 8141 syntax features that are not part of
+// the RFC 2141 syntax.
+type Features uint
+
+const (
+	FeatureResolve     Features = 1 << iota // has an r-component
+	FeatureQuery                            // has a q-component
+	FeatureFragment                         // has an f-component
+	FeatureExtendedNSS                      // NSS has "&" or "~"
+)
+
+var featureNames = []string{"r-component", "q-component", "f-component", "extended NSS"}
+
+// Has reports whether all features of x are in f.
+func (f Features) Has(x Features) bool {
+	return f&x == x
+}
+
+func (f Features) String() string {
+	if f == 0 {
+		return "none"
+	}
+
+	var names []string
+
+	for i, name := range featureNames {
+		if f&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+
+	return strings.Join(names, "+")
+}
+
+// Features returns the RFC 8141 features used by the URN, which older
+// consumers following RFC 2141 would not understand.
+func (u *URN) Features() Features {
+	var f Features
+
+	if u.Resolve != "" {
+		f |= FeatureResolve
+	}
+
+	if u.Query != "" {
+		f |= FeatureQuery
+	}
+
+	if u.Fragment != "" || u.ForceFragment {
+		f |= FeatureFragment
+	}
+
+	if strings.ContainsAny(u.NSS, "&~") {
+		f |= FeatureExtendedNSS
+	}
+
+	return f
+}
+
+// IsRFC2141Compatible reports whether the URN is also valid under the
+// RFC 2141 syntax, with the same meaning.
+func (u *URN) IsRFC2141Compatible() bool {
+	return u.Features() == 0 && !strings.EqualFold(u.NID, "urn")
+}
+
+// consumeLegacy consumes the NID and NSS of the RFC 2141 syntax:
+//
+//	<URN> ::= "urn:" <NID> ":" <NSS>
+func (p *parser) consumeLegacy() error {
+	if err := p.consumeLegacyNID(); err != nil {
+		return err
+	}
+
+	if err := p.checkNIDKind(); err != nil {
+		return err
+	}
+
+	if err := p.consumeLegacyNSS(); err != nil {
+		return err
+	}
+
+	if p.offset < p.n && !p.prefix {
+		return p.newErr(ErrInvalidNSS, ComponentNSS, p.offset, p.n-p.offset, "trailing characters")
+	}
+
+	return nil
+}
+
+// consumeLegacyNID consumes:
+//
+//	<NID> ::= <let-num> [ 1,31<let-num-hyp> ]
+func (p *parser) consumeLegacyNID() error {
+	i := p.offset
+
+	if p.eol() {
+		return p.errAt(ErrInvalidNID, ComponentNID, i, "unexpected eol")
+	}
+
+	if !isAlphaNum(p.source[i]) {
+		return p.errAt(ErrInvalidNID, ComponentNID, i, "invalid initial byte")
+	}
+
+	max := i + MaxLenNID
+	if p.n < max {
+		max = p.n
+	}
+
+	i++
+	for i < max && p.source[i] != ':' {
+		c := p.source[i]
+		if !(isAlphaNum(c) || c == '-') {
+			return p.errAt(ErrInvalidNID, ComponentNID, i, fmt.Sprintf("invalid byte at pos %d", i))
+		}
+
+		i++
+	}
+
+	if i >= p.n || p.source[i] != ':' {
+		return p.errAt(ErrInvalidNID, ComponentNID, i, "invalid final byte")
+	}
+
+	if strings.EqualFold(p.source[p.offset:i], "urn") {
+		return p.newErr(ErrInvalidNID, ComponentNID, p.offset, 3, "reserved NID")
+	}
+
+	p.id.NID = p.source[p.offset:i]
+	p.offset = i + 1
+
+	return nil
+}
+
+// consumeLegacyNSS consumes:
+//
+//	<NSS>       ::= 1*<URN chars>
+//	<URN chars> ::= <trans> | "%" <hex> <hex>
+func (p *parser) consumeLegacyNSS() error {
+	i := p.offset
+
+	for i < p.n {
+		if isLegacyTrans(p.source[i]) {
+			i++
+
+			continue
+		}
+
+		if p.maybePercentEncoded(i) {
+			i += 3
+
+			continue
+		}
+
+		break
+	}
+
+	if i == p.offset {
+		return p.errAt(ErrInvalidNSS, ComponentNSS, i, "invalid initial byte")
+	}
+
+	p.id.NSS = p.source[p.offset:i]
+	p.offset = i
+
+	return nil
+}
+
+// isLegacyTrans reports whether c is a <trans> byte other than "%":
+//
+//	<trans>    ::= <upper> | <lower> | <number> | <other> | <reserved>
+//	<other>    ::= "(" | ")" | "+" | "," | "-" | "." |
+//	               ":" | "=" | "@" | ";" | "$" |
+//	               "_" | "!" | "*" | "'"
+//	<reserved> ::= '%" | "/" | "?" | "#"
+func isLegacyTrans(c byte) bool {
+	if isAlphaNum(c) {
+		return true
+	}
+
+	switch c {
+	case '(', ')', '+', ',', '-', '.', ':', '=', '@', ';', '$', '_', '!', '*', '\'',
+		'/', '?', '#':
+		return true
+	}
+
+	return false
+}
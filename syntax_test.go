@@ -0,0 +1,119 @@
+package urn_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/paulourio/go-urn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRFC2141(t *testing.T) {
+	t.Parallel()
+
+	cases := rfc2141examples
+	cases = append(cases, rfc2141Cases...)
+
+	for i, c := range cases {
+		msg := fmt.Sprintf("case %d: %q", i+1, c.Input)
+
+		id, err := urn.ParseWithOptions(c.Input, urn.Syntax(urn.RFC2141))
+
+		if c.Err == nil {
+			if assert.NoError(t, err, msg) {
+				assert.Equal(t, c.URN, id, msg)
+				assert.Equal(t, c.Input, id.String(), msg)
+			}
+		} else {
+			assert.ErrorIs(t, err, c.Err, msg)
+			assert.Nil(t, id, msg)
+		}
+	}
+}
+
+var rfc2141Cases = []*urnTestCase{
+	// NID.
+	{
+		Input: "urn:a:b",
+		URN:   &urn.URN{Scheme: "urn", NID: "a", NSS: "b"},
+	},
+	{
+		Input: "urn:a-:b",
+		URN:   &urn.URN{Scheme: "urn", NID: "a-", NSS: "b"},
+	},
+	{
+		Input: fmt.Sprintf("urn:a%s:b", strings.Repeat("-", 31)),
+		URN:   &urn.URN{Scheme: "urn", NID: "a" + strings.Repeat("-", 31), NSS: "b"},
+	},
+	{Input: fmt.Sprintf("urn:a%s:b", strings.Repeat("-", 32)), Err: urn.ErrInvalidNID},
+	{Input: "urn:-a:b", Err: urn.ErrInvalidNID},
+	{Input: "urn:a.b:c", Err: urn.ErrInvalidNID},
+	{Input: "urn:urn:x", Err: urn.ErrInvalidNID},
+	{Input: "urn:URN:x", Err: urn.ErrInvalidNID},
+	{Input: "urn:foo", Err: urn.ErrInvalidNID},
+	// Reserved characters are part of the NSS.
+	{
+		Input: "urn:foo:a?+b",
+		URN:   &urn.URN{Scheme: "urn", NID: "foo", NSS: "a?+b"},
+	},
+	{
+		Input: "urn:foo:a?=b",
+		URN:   &urn.URN{Scheme: "urn", NID: "foo", NSS: "a?=b"},
+	},
+	{
+		Input: "urn:foo:a#b",
+		URN:   &urn.URN{Scheme: "urn", NID: "foo", NSS: "a#b"},
+	},
+	{
+		Input: "urn:foo:/a/b",
+		URN:   &urn.URN{Scheme: "urn", NID: "foo", NSS: "/a/b"},
+	},
+	{
+		Input: "urn:foo:(a)$b;c@d",
+		URN:   &urn.URN{Scheme: "urn", NID: "foo", NSS: "(a)$b;c@d"},
+	},
+	// Invalid NSS.
+	{Input: "urn:foo:", Err: urn.ErrInvalidNSS},
+	{Input: "urn:foo:a&b", Err: urn.ErrInvalidNSS},
+	{Input: "urn:foo:a~b", Err: urn.ErrInvalidNSS},
+	{Input: "urn:foo:a b", Err: urn.ErrInvalidNSS},
+	{Input: "urn:foo:a%2", Err: urn.ErrInvalidNSS},
+	{Input: "urn:foo:a%zz", Err: urn.ErrInvalidNSS},
+}
+
+func TestFeatures(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Input      string
+		Features   urn.Features
+		String     string
+		Compatible bool
+	}{
+		{"urn:foo:a123,456", 0, "none", true},
+		{"urn:foo:a?=b", urn.FeatureQuery, "q-component", false},
+		{"urn:foo:a?+r", urn.FeatureResolve, "r-component", false},
+		{"urn:foo:a#", urn.FeatureFragment, "f-component", false},
+		{"urn:foo:a~b&c", urn.FeatureExtendedNSS, "extended NSS", false},
+		{
+			"urn:foo:a?+r?=q#f",
+			urn.FeatureResolve | urn.FeatureQuery | urn.FeatureFragment,
+			"r-component+q-component+f-component",
+			false,
+		},
+		{"urn:urn:x", 0, "none", false},
+	}
+
+	for i, c := range cases {
+		msg := fmt.Sprintf("case %d: %q", i+1, c.Input)
+
+		id, err := urn.Parse(c.Input)
+		if assert.NoError(t, err, msg) {
+			assert.Equal(t, c.Features, id.Features(), msg)
+			assert.Equal(t, c.String, id.Features().String(), msg)
+			assert.Equal(t, c.Compatible, id.IsRFC2141Compatible(), msg)
+			assert.True(t, id.Features().Has(c.Features), msg)
+		}
+	}
+}
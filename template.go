@@ -0,0 +1,262 @@
+package urn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A TemplateRule maps the URNs of a NID, whose NSS matches a pattern, to
+// locations given by URI templates.
+type TemplateRule struct {
+	// NID is the namespace of the rule, compared case-insensitively.
+	NID string `json:"nid" yaml:"nid"`
+
+	// NSS is a regular expression matching the whole NSS, with named
+	// captures such as "rfc:(?P<number>[0-9]+)".  If empty, the rule
+	// matches any NSS of the namespace.
+	NSS string `json:"nss,omitempty" yaml:"nss,omitempty"`
+
+	// Templates are the RFC 6570 URI templates of the locations.
+	Templates []string `json:"templates" yaml:"templates"`
+
+	re        *regexp.Regexp
+	templates []*URITemplate
+}
+
+// TemplateConfig is the configuration file of a TemplateResolver, in
+// YAML or JSON:
+//
+//	rules:
+//	  - nid: ietf
+//	    nss: 'rfc:(?P<number>[0-9]+)'
+//	    templates:
+//	      - https://www.rfc-editor.org/rfc/rfc{number}{.format}
+type TemplateConfig struct {
+	Rules []TemplateRule `json:"rules" yaml:"rules"`
+}
+
+// TemplateResolver resolves URNs to locations with rules, each expanding
+// URI templates, from [RFC 6570](urn:ietf:rfc:6570), for the URNs it
+// matches.  The first matching rule, in order, applies.
+//
+// The variables of the templates are, by increasing precedence:
+//
+//   - the q-component values of the URN, a list if repeated;
+//   - "nid", "nss" (percent-decoded) and "urn", the assigned name;
+//   - the named captures of the NSS pattern, percent-decoded, unless
+//     they did not participate in the match.  A capture ending within a
+//     percent-encoding is an error wrapping ErrInvalidEncoding.
+//
+// NSS patterns are matched against the percent-encoding normalized NSS.
+// It implements the N2L, N2Ls, I2L and I2Ls services.
+type TemplateResolver struct {
+	rules []TemplateRule
+}
+
+// NewTemplateResolver returns a resolver with the rules.  It fails if a
+// NID, pattern or template is invalid, with an *Error wrapping
+// ErrInvalidNID or ErrInvalidTemplate.
+func NewTemplateResolver(rules ...TemplateRule) (*TemplateResolver, error) {
+	r := &TemplateResolver{rules: make([]TemplateRule, 0, len(rules))}
+
+	for i, rule := range rules {
+		if !isValidNID(rule.NID) {
+			return nil, &Error{Op: "template", Data: rule.NID, Err: ErrInvalidNID, Msg: fmt.Sprintf("rule %d", i+1)}
+		}
+
+		if len(rule.Templates) == 0 {
+			return nil, &Error{
+				Op:   "template",
+				Data: rule.NID,
+				Err:  ErrInvalidTemplate,
+				Msg:  fmt.Sprintf("rule %d: no templates", i+1),
+			}
+		}
+
+		pattern := rule.NSS
+		if pattern == "" {
+			pattern = ".*"
+		}
+
+		re, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			return nil, &Error{
+				Op:   "template",
+				Data: rule.NSS,
+				Err:  ErrInvalidTemplate,
+				Msg:  fmt.Sprintf("rule %d: %s", i+1, err),
+			}
+		}
+
+		rule.re = re
+		rule.Templates = append([]string(nil), rule.Templates...)
+		rule.templates = make([]*URITemplate, 0, len(rule.Templates))
+
+		for _, s := range rule.Templates {
+			t, err := ParseURITemplate(s)
+			if err != nil {
+				return nil, err
+			}
+
+			rule.templates = append(rule.templates, t)
+		}
+
+		r.rules = append(r.rules, rule)
+	}
+
+	return r, nil
+}
+
+// LoadTemplateResolver returns a resolver with the rules of a
+// TemplateConfig, read in YAML or JSON.  Unknown fields are errors, as
+// is invalid syntax, wrapping ErrInvalidTemplate.
+func LoadTemplateResolver(r io.Reader) (*TemplateResolver, error) {
+	var config TemplateConfig
+
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+
+	if err := dec.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, &Error{Op: "template", Data: "config", Err: ErrInvalidTemplate, Msg: err.Error()}
+	}
+
+	return NewTemplateResolver(config.Rules...)
+}
+
+// Rules returns the rules of the resolver.
+func (r *TemplateResolver) Rules() []TemplateRule {
+	return append([]TemplateRule(nil), r.rules...)
+}
+
+// NIDs returns the namespaces with rules, lowercased, in order of first
+// rule, to register the resolver with a ResolverMux.
+func (r *TemplateResolver) NIDs() []string {
+	var nids []string
+
+	seen := map[string]bool{}
+
+	for _, rule := range r.rules {
+		if nid := strings.ToLower(rule.NID); !seen[nid] {
+			seen[nid] = true
+			nids = append(nids, nid)
+		}
+	}
+
+	return nids
+}
+
+// Resolve implements Resolver.
+func (r *TemplateResolver) Resolve(_ context.Context, req *Request) (*Response, error) {
+	switch req.Service {
+	case N2L, N2Ls, I2L, I2Ls:
+	default:
+		return nil, &Error{
+			Op:   "template",
+			Data: req.URI,
+			Err:  ErrUnsupportedService,
+			Msg:  fmt.Sprintf("service %s", req.Service),
+		}
+	}
+
+	if req.URN == nil {
+		return nil, &Error{Op: "template", Data: req.URI, Err: ErrUnsupportedService, Msg: "not a URN"}
+	}
+
+	locs, err := r.locations(req.URN)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &Response{Service: req.Service, Locations: locs}
+
+	resp.truncate(req.Service)
+
+	return resp, nil
+}
+
+func (r *TemplateResolver) locations(u *URN) ([]*url.URL, error) {
+	nss := u.EncodingNormalized().NSS
+
+	for _, rule := range r.rules {
+		if !strings.EqualFold(rule.NID, u.NID) {
+			continue
+		}
+
+		m := rule.re.FindStringSubmatchIndex(nss)
+		if m == nil {
+			continue
+		}
+
+		vars := templateVars(u)
+
+		// Captures that did not participate in the match are undefined.
+		for i, name := range rule.re.SubexpNames() {
+			if name == "" || m[2*i] < 0 {
+				continue
+			}
+
+			// A capture may end within a percent-encoding.
+			v, err := DecodeString(nss[m[2*i]:m[2*i+1]])
+			if err != nil {
+				return nil, &Error{
+					Op:   "template",
+					Data: u.String(),
+					Err:  ErrInvalidEncoding,
+					Msg:  fmt.Sprintf("capture %q of rule %q", name, rule.NSS),
+				}
+			}
+
+			vars[name] = v
+		}
+
+		locs := make([]*url.URL, 0, len(rule.templates))
+
+		for _, t := range rule.templates {
+			s, err := t.Expand(vars)
+			if err != nil {
+				return nil, err
+			}
+
+			loc, err := url.Parse(s)
+			if err != nil {
+				return nil, &Error{Op: "template", Data: t.String(), Err: ErrInvalidTemplate, Msg: err.Error()}
+			}
+
+			locs = append(locs, loc)
+		}
+
+		return locs, nil
+	}
+
+	return nil, &Error{Op: "template", Data: u.AssignedName(), Err: ErrNotFound}
+}
+
+// templateVars returns the variables of the URN: its q-component values
+// and the builtin "nid", "nss" and "urn".
+func templateVars(u *URN) map[string]interface{} {
+	vars := map[string]interface{}{}
+
+	for _, p := range u.QueryValues() {
+		switch v := vars[p.Key].(type) {
+		case nil:
+			vars[p.Key] = p.Value
+		case string:
+			vars[p.Key] = []string{v, p.Value}
+		case []string:
+			vars[p.Key] = append(v, p.Value)
+		}
+	}
+
+	vars["nid"] = u.NID
+	vars["nss"] = string(Decode(u.NSS))
+	vars["urn"] = u.AssignedName()
+
+	return vars
+}
@@ -0,0 +1,236 @@
+package urn_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/paulourio/go-urn"
+	"github.com/stretchr/testify/assert"
+)
+
+const templateYAML = `
+rules:
+  - nid: ietf
+    nss: 'rfc:(?P<number>[0-9]+)'
+    templates:
+      - https://www.rfc-editor.org/rfc/rfc{number}{.format}
+      - https://datatracker.ietf.org/doc/rfc{number}/
+  - nid: ISBN
+    nss: '(?P<isbn>[0-9X-]+)(?:;(?P<edition>[^;]+))?'
+    templates:
+      - https://books.example/isbn/{isbn}{?edition,lang*}
+  - nid: example
+    templates:
+      - https://example.com/lookup{?urn}
+`
+
+func TestTemplateResolver(t *testing.T) {
+	t.Parallel()
+
+	r, err := urn.LoadTemplateResolver(strings.NewReader(templateYAML))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, []string{"ietf", "isbn", "example"}, r.NIDs())
+
+	cases := []struct {
+		Input     string
+		Service   urn.Service
+		Locations []string
+		Err       error
+	}{
+		{
+			Input:   "urn:ietf:rfc:8141",
+			Service: urn.N2Ls,
+			Locations: []string{
+				"https://www.rfc-editor.org/rfc/rfc8141",
+				"https://datatracker.ietf.org/doc/rfc8141/",
+			},
+		},
+		{
+			Input:     "urn:ietf:rfc:8141?=format=txt",
+			Service:   urn.N2L,
+			Locations: []string{"https://www.rfc-editor.org/rfc/rfc8141.txt"},
+		},
+		{
+			Input:     "URN:IETF:rfc:2141?=format=html&format=pdf",
+			Service:   urn.I2L,
+			Locations: []string{"https://www.rfc-editor.org/rfc/rfc2141.html,pdf"},
+		},
+		{
+			// Captures take precedence over the q-component.
+			Input:     "urn:ietf:rfc:8141?=number=1",
+			Service:   urn.N2L,
+			Locations: []string{"https://www.rfc-editor.org/rfc/rfc8141"},
+		},
+		{
+			Input:     "urn:isbn:0451450523",
+			Service:   urn.N2Ls,
+			Locations: []string{"https://books.example/isbn/0451450523"},
+		},
+		{
+			Input:     "urn:isbn:0451450523;2nd%20ed?=lang=en&lang=pt",
+			Service:   urn.N2Ls,
+			Locations: []string{"https://books.example/isbn/0451450523?edition=2nd%20ed&lang=en&lang=pt"},
+		},
+		{
+			Input:     "urn:example:a%2fb",
+			Service:   urn.N2L,
+			Locations: []string{"https://example.com/lookup?urn=urn%3Aexample%3Aa%252fb"},
+		},
+		{Input: "urn:ietf:bcp:14", Service: urn.N2L, Err: urn.ErrNotFound},
+		{Input: "urn:other:x", Service: urn.N2L, Err: urn.ErrNotFound},
+		{Input: "urn:ietf:rfc:8141", Service: urn.N2R, Err: urn.ErrUnsupportedService},
+	}
+
+	for i, c := range cases {
+		msg := fmt.Sprintf("case %d: %q", i+1, c.Input)
+
+		u, err := urn.Parse(c.Input)
+		if !assert.NoError(t, err, msg) {
+			continue
+		}
+
+		resp, err := r.Resolve(context.Background(), urn.NewRequest(c.Service, u))
+		if c.Err != nil {
+			assert.ErrorIs(t, err, c.Err, msg)
+			assert.Nil(t, resp, msg)
+
+			continue
+		}
+
+		if assert.NoError(t, err, msg) {
+			assert.Equal(t, c.Service, resp.Service, msg)
+			assert.Equal(t, c.Locations, locationStrings(resp.Locations), msg)
+		}
+	}
+}
+
+func TestTemplateResolverJSON(t *testing.T) {
+	t.Parallel()
+
+	r, err := urn.LoadTemplateResolver(strings.NewReader(`{
+		"rules": [
+			{"nid": "nbn", "nss": "de:(?P<rest>.*)", "templates": ["https://nbn-resolving.org/urn:nbn:de:{rest}"]}
+		]
+	}`))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if assert.Len(t, r.Rules(), 1) {
+		assert.Equal(t, "nbn", r.Rules()[0].NID)
+	}
+
+	m := urn.NewResolverMux()
+	for _, nid := range r.NIDs() {
+		assert.NoError(t, m.Handle(nid, r))
+	}
+
+	resp, err := m.Resolve(context.Background(), &urn.Request{Service: urn.N2L, URI: "urn:nbn:de:101:1-2011"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "https://nbn-resolving.org/urn:nbn:de:101%3A1-2011", resp.Location().String())
+	}
+}
+
+func TestTemplateResolverErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Input string
+		Err   error
+	}{
+		{Input: "rules: [{nid: a, templates: [x]}]", Err: urn.ErrInvalidNID},
+		{Input: "rules: [{nid: abc}]", Err: urn.ErrInvalidTemplate},
+		{Input: "rules: [{nid: abc, nss: '(', templates: [x]}]", Err: urn.ErrInvalidTemplate},
+		{Input: "rules: [{nid: abc, templates: ['{x']}]", Err: urn.ErrInvalidTemplate},
+		{Input: "rules: [{nid: abc, template: [x]}]", Err: urn.ErrInvalidTemplate},
+		{Input: "rules: {nid: abc}", Err: urn.ErrInvalidTemplate},
+	}
+
+	for i, c := range cases {
+		msg := fmt.Sprintf("case %d: %q", i+1, c.Input)
+
+		_, err := urn.LoadTemplateResolver(strings.NewReader(c.Input))
+
+		var uerr *urn.Error
+		if assert.ErrorAs(t, err, &uerr, msg) {
+			assert.Equal(t, "template", uerr.Op, msg)
+			assert.ErrorIs(t, err, c.Err, msg)
+		}
+	}
+
+	r, err := urn.LoadTemplateResolver(strings.NewReader(""))
+	if assert.NoError(t, err) {
+		assert.Empty(t, r.Rules())
+	}
+}
+
+func TestTemplateResolverResolveErrors(t *testing.T) {
+	t.Parallel()
+
+	r, err := urn.NewTemplateResolver(
+		urn.TemplateRule{NID: "example", NSS: "(?P<head>.{3}).*", Templates: []string{"https://example.com/{head}"}},
+		urn.TemplateRule{NID: "other", Templates: []string{"https://example.com/{x:2}"}},
+	)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	cases := []struct {
+		Input   string
+		Service urn.Service
+		Err     error
+		Message string
+	}{
+		{
+			// The capture ends within "%20".
+			Input:   "urn:example:a%20b",
+			Service: urn.N2L,
+			Err:     urn.ErrInvalidEncoding,
+			Message: `template "urn:example:a%20b": invalid percent-encoding: capture "head" of rule "(?P<head>.{3}).*"`,
+		},
+		{
+			Input:   "urn:other:a?=x=1&x=2",
+			Service: urn.N2L,
+			Err:     urn.ErrInvalidTemplate,
+			Message: `template "https://example.com/{x:2}": invalid template: prefix modifier applied to a list`,
+		},
+		{
+			Input:   "urn:unknown:a",
+			Service: urn.N2L,
+			Err:     urn.ErrNotFound,
+			Message: `template "urn:unknown:a": not found`,
+		},
+		{
+			Input:   "urn:example:abcd",
+			Service: urn.N2R,
+			Err:     urn.ErrUnsupportedService,
+			Message: `template "urn:example:abcd": unsupported service: service N2R`,
+		},
+	}
+
+	for i, c := range cases {
+		msg := fmt.Sprintf("case %d: %q", i+1, c.Input)
+
+		u, err := urn.Parse(c.Input)
+		if !assert.NoError(t, err, msg) {
+			continue
+		}
+
+		_, err = r.Resolve(context.Background(), urn.NewRequest(c.Service, u))
+
+		var uerr *urn.Error
+		if assert.ErrorAs(t, err, &uerr, msg) {
+			assert.ErrorIs(t, err, c.Err, msg)
+			assert.Equal(t, c.Message, uerr.Error(), msg)
+		}
+	}
+
+	resp, err := r.Resolve(context.Background(), &urn.Request{Service: urn.N2L, URI: "urn:example:a%2F"})
+	assert.ErrorIs(t, err, urn.ErrUnsupportedService)
+	assert.Nil(t, resp)
+}
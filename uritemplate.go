@@ -0,0 +1,418 @@
+package urn
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// A URITemplate is a URI Template, from [RFC 6570](urn:ietf:rfc:6570),
+// supporting all expression operators and variable modifiers (level 4).
+//
+//	t, _ := urn.ParseURITemplate("https://www.rfc-editor.org/rfc/rfc{n}{.format}")
+//	t.Expand(map[string]interface{}{"n": "8141", "format": "txt"})
+//	// https://www.rfc-editor.org/rfc/rfc8141.txt
+type URITemplate struct {
+	raw   string
+	parts []templatePart
+}
+
+// A templatePart is a literal, if op is nil, or an expression.
+type templatePart struct {
+	literal string
+	op      *templateOp
+	vars    []templateVar
+
+	offset, length int // of the expression in the template
+}
+
+type templateVar struct {
+	name    string
+	prefix  int // maximum length in characters, or zero
+	explode bool
+}
+
+// templateOp is an expression operator, from
+// [RFC 6570 Appendix A](urn:ietf:rfc:6570#appendix-A).
+type templateOp struct {
+	first    string
+	sep      string
+	named    bool
+	ifEmpty  string
+	reserved bool // allow reserved characters
+}
+
+var templateOps = map[byte]*templateOp{
+	0:   {first: "", sep: ","},
+	'+': {first: "", sep: ",", reserved: true},
+	'.': {first: ".", sep: "."},
+	'/': {first: "/", sep: "/"},
+	';': {first: ";", sep: ";", named: true},
+	'?': {first: "?", sep: "&", named: true, ifEmpty: "="},
+	'&': {first: "&", sep: "&", named: true, ifEmpty: "="},
+	'#': {first: "#", sep: ",", reserved: true},
+}
+
+// ParseURITemplate parses a URI Template.  Errors are of type *Error,
+// wrap ErrInvalidTemplate, and locate the offending expression.
+func ParseURITemplate(s string) (*URITemplate, error) {
+	t := &URITemplate{raw: s}
+
+	for off := 0; off < len(s); {
+		i := strings.IndexAny(s[off:], "{}")
+		if i < 0 {
+			t.parts = append(t.parts, templatePart{literal: s[off:]})
+
+			break
+		}
+
+		i += off
+
+		if s[i] == '}' {
+			return nil, templateError(s, i, 1, "unmatched '}'")
+		}
+
+		if i > off {
+			t.parts = append(t.parts, templatePart{literal: s[off:i]})
+		}
+
+		end := strings.IndexByte(s[i:], '}')
+		if end < 0 {
+			return nil, templateError(s, i, len(s)-i, "unterminated expression")
+		}
+
+		end += i + 1
+
+		part, err := parseTemplateExpr(s[i+1 : end-1])
+		if err != nil {
+			return nil, templateError(s, i, end-i, err.Error())
+		}
+
+		part.offset, part.length = i, end-i
+		t.parts = append(t.parts, part)
+		off = end
+	}
+
+	return t, nil
+}
+
+// templateError returns the error of the template at the offset.
+func templateError(s string, offset, length int, msg string) error {
+	return &Error{
+		Op:     "template",
+		Data:   s,
+		Err:    ErrInvalidTemplate,
+		Msg:    msg,
+		Offset: offset,
+		Length: length,
+	}
+}
+
+func parseTemplateExpr(s string) (templatePart, error) {
+	var op byte
+
+	if s != "" && strings.IndexByte("+#./;?&", s[0]) >= 0 {
+		op, s = s[0], s[1:]
+	} else if s != "" && strings.IndexByte("=,!@|", s[0]) >= 0 {
+		return templatePart{}, fmt.Errorf("reserved operator %q", s[0])
+	}
+
+	part := templatePart{op: templateOps[op]}
+
+	for _, spec := range strings.Split(s, ",") {
+		v := templateVar{name: spec}
+
+		if strings.HasSuffix(spec, "*") {
+			v.name, v.explode = spec[:len(spec)-1], true
+		} else if i := strings.IndexByte(spec, ':'); i >= 0 {
+			n := 0
+
+			for _, c := range []byte(spec[i+1:]) {
+				if !isDigit(c) || n == 0 && c == '0' {
+					return templatePart{}, fmt.Errorf("invalid prefix in %q", spec)
+				}
+
+				n = n*10 + int(c-'0')
+			}
+
+			if n == 0 || n > 9999 {
+				return templatePart{}, fmt.Errorf("invalid prefix in %q", spec)
+			}
+
+			v.name, v.prefix = spec[:i], n
+		}
+
+		if !isTemplateVarName(v.name) {
+			return templatePart{}, fmt.Errorf("invalid variable name %q", v.name)
+		}
+
+		part.vars = append(part.vars, v)
+	}
+
+	return part, nil
+}
+
+// isTemplateVarName reports whether s is a varname:
+//
+//	varname = varchar *( ["."] varchar )
+//	varchar = ALPHA / DIGIT / "_" / pct-encoded
+func isTemplateVarName(s string) bool {
+	if s == "" || s[0] == '.' || s[len(s)-1] == '.' || strings.Contains(s, "..") {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case isAlphaNum(c), c == '_', c == '.':
+		case c == '%' && i+2 < len(s) && isHex(s[i+1]) && isHex(s[i+2]):
+			i += 2
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// String returns the template text.
+func (t *URITemplate) String() string {
+	return t.raw
+}
+
+// Varnames returns the names of the variables of the template, in
+// order of first use.
+func (t *URITemplate) Varnames() []string {
+	var names []string
+
+	seen := map[string]bool{}
+
+	for _, p := range t.parts {
+		for _, v := range p.vars {
+			if !seen[v.name] {
+				seen[v.name] = true
+				names = append(names, v.name)
+			}
+		}
+	}
+
+	return names
+}
+
+// Expand expands the template with the values of the variables.  Values
+// are a string, a list of strings ([]string), or an associative array
+// (Params).  Variables that are missing, nil, empty lists and empty
+// associative arrays are undefined, and are omitted.  Values of another
+// type, and prefix modifiers applied to lists or associative arrays,
+// are errors wrapping ErrInvalidTemplate.
+func (t *URITemplate) Expand(vars map[string]interface{}) (string, error) {
+	var b strings.Builder
+
+	for _, p := range t.parts {
+		if p.op == nil {
+			encodeTemplateLiteral(&b, p.literal)
+
+			continue
+		}
+
+		if err := p.expand(&b, vars); err != nil {
+			return "", templateError(t.raw, p.offset, p.length, err.Error())
+		}
+	}
+
+	return b.String(), nil
+}
+
+func (p *templatePart) expand(b *strings.Builder, vars map[string]interface{}) error {
+	op := p.op
+	first := true
+
+	for _, v := range p.vars {
+		value := vars[v.name]
+
+		switch x := value.(type) {
+		case nil:
+			continue
+		case []string:
+			if len(x) == 0 {
+				continue
+			}
+		case Params:
+			if len(x) == 0 {
+				continue
+			}
+		case string:
+		default:
+			return fmt.Errorf("unsupported value %T of %q", value, v.name)
+		}
+
+		if first {
+			b.WriteString(op.first)
+			first = false
+		} else {
+			b.WriteString(op.sep)
+		}
+
+		switch x := value.(type) {
+		case string:
+			if op.named {
+				b.WriteString(v.name)
+
+				if x == "" {
+					b.WriteString(op.ifEmpty)
+
+					continue
+				}
+
+				b.WriteByte('=')
+			}
+
+			if v.prefix > 0 {
+				x = truncateRunes(x, v.prefix)
+			}
+
+			encodeTemplateValue(b, x, op.reserved)
+		case []string:
+			if v.prefix > 0 {
+				return errors.New("prefix modifier applied to a list")
+			}
+
+			p.expandList(b, v, x)
+		case Params:
+			if v.prefix > 0 {
+				return errors.New("prefix modifier applied to an associative array")
+			}
+
+			p.expandParams(b, v, x)
+		}
+	}
+
+	return nil
+}
+
+func (p *templatePart) expandList(b *strings.Builder, v templateVar, list []string) {
+	op := p.op
+
+	if !v.explode {
+		if op.named {
+			b.WriteString(v.name)
+			b.WriteByte('=')
+		}
+
+		for i, s := range list {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+
+			encodeTemplateValue(b, s, op.reserved)
+		}
+
+		return
+	}
+
+	for i, s := range list {
+		if i > 0 {
+			b.WriteString(op.sep)
+		}
+
+		if op.named {
+			b.WriteString(v.name)
+
+			if s == "" {
+				b.WriteString(op.ifEmpty)
+
+				continue
+			}
+
+			b.WriteByte('=')
+		}
+
+		encodeTemplateValue(b, s, op.reserved)
+	}
+}
+
+func (p *templatePart) expandParams(b *strings.Builder, v templateVar, params Params) {
+	op := p.op
+
+	if !v.explode {
+		if op.named {
+			b.WriteString(v.name)
+			b.WriteByte('=')
+		}
+
+		for i, kv := range params {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+
+			encodeTemplateValue(b, kv.Key, op.reserved)
+			b.WriteByte(',')
+			encodeTemplateValue(b, kv.Value, op.reserved)
+		}
+
+		return
+	}
+
+	for i, kv := range params {
+		if i > 0 {
+			b.WriteString(op.sep)
+		}
+
+		encodeTemplateValue(b, kv.Key, op.reserved)
+
+		if op.named && kv.Value == "" {
+			b.WriteString(op.ifEmpty)
+
+			continue
+		}
+
+		b.WriteByte('=')
+		encodeTemplateValue(b, kv.Value, op.reserved)
+	}
+}
+
+// encodeTemplateValue percent-encodes s, keeping unreserved characters
+// and, if reserved is set, reserved characters and percent-encodings.
+func encodeTemplateValue(b *strings.Builder, s string, reserved bool) {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case isUnreserved(c):
+			b.WriteByte(c)
+		case reserved && (isGenDelim(c) || isSubDelim(c)):
+			b.WriteByte(c)
+		case reserved && c == '%' && i+2 < len(s) && isHex(s[i+1]) && isHex(s[i+2]):
+			b.WriteString(s[i : i+3])
+			i += 2
+		default:
+			b.WriteByte('%')
+			b.WriteByte(upperHex[c>>4])
+			b.WriteByte(upperHex[c&0xF])
+		}
+	}
+}
+
+// encodeTemplateLiteral copies a literal, percent-encoding the
+// characters not allowed in URIs.
+func encodeTemplateLiteral(b *strings.Builder, s string) {
+	encodeTemplateValue(b, s, true)
+}
+
+func isGenDelim(c byte) bool {
+	return strings.IndexByte(":/?#[]@", c) >= 0
+}
+
+// truncateRunes returns the first n characters of s.
+func truncateRunes(s string, n int) string {
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+
+		n--
+	}
+
+	return s
+}
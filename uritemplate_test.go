@@ -0,0 +1,272 @@
+package urn_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/paulourio/go-urn"
+	"github.com/stretchr/testify/assert"
+)
+
+// The examples of RFC 6570 §3.
+var templateVars = map[string]interface{}{
+	"count": []string{"one", "two", "three"},
+	"dom":   []string{"example", "com"},
+	"dub":   "me/too",
+	"hello": "Hello World!",
+	"half":  "50%",
+	"var":   "value",
+	"who":   "fred",
+	"base":  "http://example.com/home/",
+	"path":  "/foo/bar",
+	"list":  []string{"red", "green", "blue"},
+	"keys": urn.Params{
+		{Key: "semi", Value: ";"},
+		{Key: "dot", Value: "."},
+		{Key: "comma", Value: ","},
+	},
+	"v":          "6",
+	"x":          "1024",
+	"y":          "768",
+	"empty":      "",
+	"empty_keys": urn.Params{},
+}
+
+func TestURITemplateExpand(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Input  string
+		Output string
+	}{
+		// Literals.
+		{"https://example.com/a b", "https://example.com/a%20b"},
+		{"https://example.com/%7e/é", "https://example.com/%7e/%C3%A9"},
+		// Simple string expansion.
+		{"{var}", "value"},
+		{"{hello}", "Hello%20World%21"},
+		{"{half}", "50%25"},
+		{"O{empty}X", "OX"},
+		{"O{undef}X", "OX"},
+		{"{x,y}", "1024,768"},
+		{"{x,hello,y}", "1024,Hello%20World%21,768"},
+		{"?{x,empty}", "?1024,"},
+		{"?{x,undef}", "?1024"},
+		{"?{undef,y}", "?768"},
+		{"{var:3}", "val"},
+		{"{var:30}", "value"},
+		{"{list}", "red,green,blue"},
+		{"{list*}", "red,green,blue"},
+		{"{keys}", "semi,%3B,dot,.,comma,%2C"},
+		{"{keys*}", "semi=%3B,dot=.,comma=%2C"},
+		// Reserved expansion.
+		{"{+var}", "value"},
+		{"{+hello}", "Hello%20World!"},
+		{"{+half}", "50%25"},
+		{"{base}index", "http%3A%2F%2Fexample.com%2Fhome%2Findex"},
+		{"{+base}index", "http://example.com/home/index"},
+		{"O{+empty}X", "OX"},
+		{"{+path}/here", "/foo/bar/here"},
+		{"here?ref={+path}", "here?ref=/foo/bar"},
+		{"up{+path}{var}/here", "up/foo/barvalue/here"},
+		{"{+x,hello,y}", "1024,Hello%20World!,768"},
+		{"{+path,x}/here", "/foo/bar,1024/here"},
+		{"{+path:6}/here", "/foo/b/here"},
+		{"{+list*}", "red,green,blue"},
+		{"{+keys}", "semi,;,dot,.,comma,,"},
+		{"{+keys*}", "semi=;,dot=.,comma=,"},
+		// Fragment expansion.
+		{"{#var}", "#value"},
+		{"{#hello}", "#Hello%20World!"},
+		{"{#half}", "#50%25"},
+		{"foo{#empty}", "foo#"},
+		{"foo{#undef}", "foo"},
+		{"{#x,hello,y}", "#1024,Hello%20World!,768"},
+		{"{#path,x}/here", "#/foo/bar,1024/here"},
+		{"{#path:6}/here", "#/foo/b/here"},
+		{"{#list}", "#red,green,blue"},
+		{"{#list*}", "#red,green,blue"},
+		{"{#keys}", "#semi,;,dot,.,comma,,"},
+		{"{#keys*}", "#semi=;,dot=.,comma=,"},
+		// Label expansion.
+		{"{.who}", ".fred"},
+		{"{.who,who}", ".fred.fred"},
+		{"{.half,who}", ".50%25.fred"},
+		{"www{.dom*}", "www.example.com"},
+		{"X{.var}", "X.value"},
+		{"X{.empty}", "X."},
+		{"X{.undef}", "X"},
+		{"X{.var:3}", "X.val"},
+		{"X{.list}", "X.red,green,blue"},
+		{"X{.list*}", "X.red.green.blue"},
+		{"X{.keys}", "X.semi,%3B,dot,.,comma,%2C"},
+		{"X{.keys*}", "X.semi=%3B.dot=..comma=%2C"},
+		{"X{.empty_keys}", "X"},
+		{"X{.empty_keys*}", "X"},
+		// Path segment expansion.
+		{"{/who}", "/fred"},
+		{"{/who,who}", "/fred/fred"},
+		{"{/half,who}", "/50%25/fred"},
+		{"{/who,dub}", "/fred/me%2Ftoo"},
+		{"{/var}", "/value"},
+		{"{/var,empty}", "/value/"},
+		{"{/var,undef}", "/value"},
+		{"{/var,x}/here", "/value/1024/here"},
+		{"{/var:1,var}", "/v/value"},
+		{"{/list}", "/red,green,blue"},
+		{"{/list*}", "/red/green/blue"},
+		{"{/list*,path:4}", "/red/green/blue/%2Ffoo"},
+		{"{/keys}", "/semi,%3B,dot,.,comma,%2C"},
+		{"{/keys*}", "/semi=%3B/dot=./comma=%2C"},
+		// Path-style parameter expansion.
+		{"{;who}", ";who=fred"},
+		{"{;half}", ";half=50%25"},
+		{"{;empty}", ";empty"},
+		{"{;v,empty,who}", ";v=6;empty;who=fred"},
+		{"{;v,bar,who}", ";v=6;who=fred"},
+		{"{;x,y}", ";x=1024;y=768"},
+		{"{;x,y,empty}", ";x=1024;y=768;empty"},
+		{"{;x,y,undef}", ";x=1024;y=768"},
+		{"{;hello:5}", ";hello=Hello"},
+		{"{;list}", ";list=red,green,blue"},
+		{"{;list*}", ";list=red;list=green;list=blue"},
+		{"{;keys}", ";keys=semi,%3B,dot,.,comma,%2C"},
+		{"{;keys*}", ";semi=%3B;dot=.;comma=%2C"},
+		// Form-style query expansion.
+		{"{?who}", "?who=fred"},
+		{"{?half}", "?half=50%25"},
+		{"{?x,y}", "?x=1024&y=768"},
+		{"{?x,y,empty}", "?x=1024&y=768&empty="},
+		{"{?x,y,undef}", "?x=1024&y=768"},
+		{"{?var:3}", "?var=val"},
+		{"{?list}", "?list=red,green,blue"},
+		{"{?list*}", "?list=red&list=green&list=blue"},
+		{"{?keys}", "?keys=semi,%3B,dot,.,comma,%2C"},
+		{"{?keys*}", "?semi=%3B&dot=.&comma=%2C"},
+		// Form-style query continuation.
+		{"{&who}", "&who=fred"},
+		{"{&half}", "&half=50%25"},
+		{"?fixed=yes{&x}", "?fixed=yes&x=1024"},
+		{"{&x,y,empty}", "&x=1024&y=768&empty="},
+		{"{&var:3}", "&var=val"},
+		{"{&list}", "&list=red,green,blue"},
+		{"{&list*}", "&list=red&list=green&list=blue"},
+		{"{&keys}", "&keys=semi,%3B,dot,.,comma,%2C"},
+		{"{&keys*}", "&semi=%3B&dot=.&comma=%2C"},
+	}
+
+	for i, c := range cases {
+		msg := fmt.Sprintf("case %d: %q", i+1, c.Input)
+
+		tmpl, err := urn.ParseURITemplate(c.Input)
+		if !assert.NoError(t, err, msg) {
+			continue
+		}
+
+		assert.Equal(t, c.Input, tmpl.String(), msg)
+
+		out, err := tmpl.Expand(templateVars)
+		if assert.NoError(t, err, msg) {
+			assert.Equal(t, c.Output, out, msg)
+		}
+	}
+}
+
+func TestURITemplatePrefixUnicode(t *testing.T) {
+	t.Parallel()
+
+	tmpl, err := urn.ParseURITemplate("{v:2}")
+	if assert.NoError(t, err) {
+		out, err := tmpl.Expand(map[string]interface{}{"v": "ééé"})
+		assert.NoError(t, err)
+		assert.Equal(t, "%C3%A9%C3%A9", out)
+	}
+}
+
+func TestURITemplateErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := []string{
+		"{",
+		"}",
+		"{var",
+		"a}b",
+		"{}",
+		"{=var}",
+		"{var:0}",
+		"{var:10000}",
+		"{var:x}",
+		"{.var.}",
+		"{a b}",
+		"{x,}",
+	}
+
+	for i, c := range cases {
+		msg := fmt.Sprintf("case %d: %q", i+1, c)
+
+		_, err := urn.ParseURITemplate(c)
+		assert.ErrorIs(t, err, urn.ErrInvalidTemplate, msg)
+	}
+
+	tmpl, err := urn.ParseURITemplate("{list:2}")
+	if assert.NoError(t, err) {
+		_, err = tmpl.Expand(templateVars)
+		assert.ErrorIs(t, err, urn.ErrInvalidTemplate)
+
+		_, err = tmpl.Expand(map[string]interface{}{"list": 1})
+		assert.ErrorIs(t, err, urn.ErrInvalidTemplate)
+	}
+}
+
+func TestURITemplateErrorPosition(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Input  string
+		Pretty string
+	}{
+		{
+			Input:  "a}b",
+			Pretty: "template \"a}b\": invalid template: unmatched '}'\n  a}b\n   ^",
+		},
+		{
+			Input:  "x{var",
+			Pretty: "template \"x{var\": invalid template: unterminated expression\n  x{var\n   ^~~~",
+		},
+		{
+			Input:  "{a}/{b:0}",
+			Pretty: "template \"{a}/{b:0}\": invalid template: invalid prefix in \"b:0\"\n  {a}/{b:0}\n      ^~~~~",
+		},
+	}
+
+	for i, c := range cases {
+		msg := fmt.Sprintf("case %d: %q", i+1, c.Input)
+
+		_, err := urn.ParseURITemplate(c.Input)
+
+		var uerr *urn.Error
+		if assert.ErrorAs(t, err, &uerr, msg) {
+			assert.Equal(t, c.Pretty, uerr.Pretty(), msg)
+		}
+	}
+
+	tmpl, err := urn.ParseURITemplate("{a}/{list:2}")
+	if assert.NoError(t, err) {
+		_, err = tmpl.Expand(templateVars)
+
+		var uerr *urn.Error
+		if assert.ErrorAs(t, err, &uerr) {
+			assert.Equal(t, 4, uerr.Offset)
+			assert.Equal(t, 8, uerr.Length)
+		}
+	}
+}
+
+func TestURITemplateVarnames(t *testing.T) {
+	t.Parallel()
+
+	tmpl, err := urn.ParseURITemplate("https://example.com{/a,b}{?b*,c:2}#x")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"a", "b", "c"}, tmpl.Varnames())
+	}
+}
@@ -27,6 +27,38 @@ type URN struct {
 	normalized bool
 }
 
+// Component identifies a part of a URN.
+type Component int
+
+const (
+	ComponentUnknown  Component = iota
+	ComponentScheme             // "urn"
+	ComponentNID                // namespace identifier
+	ComponentNSS                // namespace specific string
+	ComponentResolve            // r-component
+	ComponentQuery              // q-component
+	ComponentFragment           // f-component
+)
+
+func (c Component) String() string {
+	switch c {
+	case ComponentScheme:
+		return "scheme"
+	case ComponentNID:
+		return "NID"
+	case ComponentNSS:
+		return "NSS"
+	case ComponentResolve:
+		return "r-component"
+	case ComponentQuery:
+		return "q-component"
+	case ComponentFragment:
+		return "f-component"
+	}
+
+	return "unknown"
+}
+
 // AssignedName returns schema:nid:nss.
 func (u *URN) AssignedName() string {
 	var b strings.Builder
@@ -123,6 +155,12 @@ func (u *URN) Normalized() *URN {
 	return n
 }
 
+// NamespaceNormalized returns a copy of the identifier normalized with
+// the lexical equivalence rules of its namespace in the DefaultRegistry.
+func (u *URN) NamespaceNormalized() *URN {
+	return DefaultRegistry.Normalized(u)
+}
+
 // String returns the complete identifier, including components.
 func (u *URN) String() string {
 	var b strings.Builder
@@ -202,3 +240,33 @@ func (u *URN) UnmarshalText(b []byte) error {
 	*u = *n
 	return nil
 }
+
+// MarshalBinary marshals the URN as its text, for encoding/gob.
+func (u *URN) MarshalBinary() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalBinary parses URN input marshaled by MarshalBinary.
+func (u *URN) UnmarshalBinary(b []byte) error {
+	n, err := Parse(string(b))
+	if err != nil {
+		return fmt.Errorf("urn.UnmarshalBinary: %w", err)
+	}
+
+	*u = *n
+	return nil
+}
+
+// Set parses s into the URN, so that a URN can be used as a flag.Value:
+//
+//	var id urn.URN
+//	flag.Var(&id, "id", "resource `urn`")
+func (u *URN) Set(s string) error {
+	n, err := Parse(s)
+	if err != nil {
+		return err
+	}
+
+	*u = *n
+	return nil
+}
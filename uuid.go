@@ -0,0 +1,232 @@
+package urn
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+// UUIDNamespace implements the "uuid" namespace from
+// [RFC 4122](urn:ietf:rfc:4122), updated by [RFC 9562](urn:ietf:rfc:9562).
+// The NSS is the hexadecimal string representation of the UUID, which is
+// case-insensitive and normalized to lowercase.
+var UUIDNamespace Namespace = uuidNamespace{}
+
+type uuidNamespace struct{}
+
+func (uuidNamespace) NID() string { return "uuid" }
+
+func (uuidNamespace) Validate(nss string) error {
+	_, err := decodeUUID(nss)
+
+	return err
+}
+
+func (uuidNamespace) Normalize(nss string) string {
+	return strings.ToLower(nss)
+}
+
+// A UUID is a 128-bit Universally Unique IDentifier.
+type UUID [16]byte
+
+// UUIDVariant is the layout variant of a UUID, as given by the most
+// significant bits of its 8th octet.
+type UUIDVariant int
+
+const (
+	UUIDVariantNCS       UUIDVariant = iota // reserved, NCS backward compatibility
+	UUIDVariantRFC                          // layout specified by RFC 9562
+	UUIDVariantMicrosoft                    // reserved, Microsoft backward compatibility
+	UUIDVariantFuture                       // reserved for future definition
+)
+
+// ParseUUID parses the string representation of a UUID, such as
+// "f81d4fae-7dec-11d0-a765-00a0c91e6bf6", in any letter case.
+func ParseUUID(s string) (UUID, error) {
+	id, err := decodeUUID(s)
+	if err != nil {
+		return id, &Error{Op: "uuid", Data: s, Err: ErrInvalidNSS, Msg: err.Error()}
+	}
+
+	return id, nil
+}
+
+// UUIDFromURN returns the UUID identified by a "uuid" URN.
+func UUIDFromURN(u *URN) (UUID, error) {
+	if !strings.EqualFold(u.NID, "uuid") {
+		return UUID{}, &Error{
+			Op:   "uuid",
+			Data: u.String(),
+			Err:  ErrInvalidNID,
+			Msg:  "not a uuid namespace",
+		}
+	}
+
+	id, err := decodeUUID(u.NSS)
+	if err != nil {
+		return id, &Error{Op: "uuid", Data: u.String(), Err: ErrInvalidNSS, Msg: err.Error()}
+	}
+
+	return id, nil
+}
+
+// String returns the lowercase string representation of the UUID.
+func (id UUID) String() string {
+	var b [36]byte
+
+	j := 0
+
+	for i, c := range id {
+		switch i {
+		case 4, 6, 8, 10:
+			b[j] = '-'
+			j++
+		}
+
+		b[j] = lowerHex[c>>4]
+		b[j+1] = lowerHex[c&0xF]
+		j += 2
+	}
+
+	return string(b[:])
+}
+
+// URN returns the normalized "uuid" URN of the UUID.
+func (id UUID) URN() *URN {
+	return &URN{
+		Scheme:     "urn",
+		NID:        "uuid",
+		NSS:        id.String(),
+		normalized: true,
+	}
+}
+
+// Version returns the version number of the UUID.  The version is only
+// meaningful for UUIDs of the UUIDVariantRFC variant.
+func (id UUID) Version() int {
+	return int(id[6] >> 4)
+}
+
+// Variant returns the layout variant of the UUID.
+func (id UUID) Variant() UUIDVariant {
+	switch {
+	case id[8]&0x80 == 0x00:
+		return UUIDVariantNCS
+	case id[8]&0xC0 == 0x80:
+		return UUIDVariantRFC
+	case id[8]&0xE0 == 0xC0:
+		return UUIDVariantMicrosoft
+	}
+
+	return UUIDVariantFuture
+}
+
+// UUIDGenerator generates "uuid" URNs for new UUIDs.  The zero value is
+// ready to use, reading from crypto/rand and the system clock.
+type UUIDGenerator struct {
+	Rand io.Reader        // source of random bytes; crypto/rand if nil
+	Now  func() time.Time // clock for time-based versions; time.Now if nil
+}
+
+// NewV4 returns the URN of a new random (version 4) UUID.
+func (g *UUIDGenerator) NewV4() (*URN, error) {
+	var id UUID
+
+	if _, err := io.ReadFull(g.rand(), id[:]); err != nil {
+		return nil, err
+	}
+
+	id.setVersion(4)
+
+	return id.URN(), nil
+}
+
+// NewV7 returns the URN of a new time-ordered (version 7) UUID, made of
+// the 48-bit Unix timestamp in milliseconds followed by random bits.
+func (g *UUIDGenerator) NewV7() (*URN, error) {
+	var (
+		id UUID
+		ts [8]byte
+	)
+
+	if _, err := io.ReadFull(g.rand(), id[6:]); err != nil {
+		return nil, err
+	}
+
+	now := g.now()
+
+	ms := now.UnixMilli()
+	if ms < 0 || ms >= 1<<48 {
+		return nil, &Error{
+			Op:   "uuid",
+			Data: now.Format(time.RFC3339Nano),
+			Err:  ErrTimeOutOfRange,
+			Msg:  "not a 48-bit Unix timestamp in milliseconds",
+		}
+	}
+
+	binary.BigEndian.PutUint64(ts[:], uint64(ms))
+	copy(id[:6], ts[2:])
+	id.setVersion(7)
+
+	return id.URN(), nil
+}
+
+func (g *UUIDGenerator) rand() io.Reader {
+	if g.Rand == nil {
+		return rand.Reader
+	}
+
+	return g.Rand
+}
+
+func (g *UUIDGenerator) now() time.Time {
+	if g.Now == nil {
+		return time.Now()
+	}
+
+	return g.Now()
+}
+
+func (id *UUID) setVersion(v byte) {
+	id[6] = id[6]&0x0F | v<<4
+	id[8] = id[8]&0x3F | 0x80
+}
+
+func decodeUUID(s string) (UUID, error) {
+	var id UUID
+
+	if len(s) != 36 {
+		return id, errors.New("invalid length")
+	}
+
+	j := 0
+
+	for i := 0; i < len(s); {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return id, errors.New("expected hyphen")
+			}
+
+			i++
+
+			continue
+		}
+
+		if !isHex(s[i]) || !isHex(s[i+1]) {
+			return id, errors.New("invalid hex digit")
+		}
+
+		id[j] = unhex(s[i])<<4 | unhex(s[i+1])
+		i += 2
+		j++
+	}
+
+	return id, nil
+}
+
+const lowerHex = "0123456789abcdef"
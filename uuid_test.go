@@ -0,0 +1,197 @@
+package urn_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/paulourio/go-urn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseUUID(t *testing.T) {
+	t.Parallel()
+
+	for i, c := range uuidCases {
+		msg := fmt.Sprintf("case %d: %q", i+1, c.Input)
+
+		id, err := urn.ParseUUID(c.Input)
+		if c.Err {
+			assert.ErrorIs(t, err, urn.ErrInvalidNSS, msg)
+
+			continue
+		}
+
+		if assert.NoError(t, err, msg) {
+			assert.Equal(t, c.Output, id.String(), msg)
+			assert.Equal(t, c.Version, id.Version(), msg)
+			assert.Equal(t, c.Variant, id.Variant(), msg)
+
+			u := id.URN()
+			assert.Equal(t, "urn:uuid:"+c.Output, u.String(), msg)
+
+			back, err := urn.UUIDFromURN(u)
+			if assert.NoError(t, err, msg) {
+				assert.Equal(t, id, back, msg)
+			}
+		}
+	}
+}
+
+func TestUUIDNamespace(t *testing.T) {
+	t.Parallel()
+
+	_, err := urn.DefaultRegistry.Parse("urn:uuid:not-a-uuid")
+	assert.ErrorIs(t, err, urn.ErrInvalidNSS)
+
+	a, err := urn.DefaultRegistry.Parse("urn:UUID:F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6")
+	if assert.NoError(t, err) {
+		b, _ := urn.Parse("urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6")
+
+		assert.True(t, urn.Equal(a, b, urn.AssignedName, urn.NamespaceNormalized))
+		assert.False(t, urn.Equal(a, b, urn.AssignedName, urn.CaseNormalized))
+	}
+
+	u, _ := urn.Parse("urn:oid:1.2.3")
+	_, err = urn.UUIDFromURN(u)
+	assert.ErrorIs(t, err, urn.ErrInvalidNID)
+}
+
+func TestUUIDGenerator(t *testing.T) {
+	t.Parallel()
+
+	g := &urn.UUIDGenerator{
+		Rand: bytes.NewReader(bytes.Repeat([]byte{0xFF}, 32)),
+		Now: func() time.Time {
+			return time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)
+		},
+	}
+
+	u, err := g.NewV4()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "urn:uuid:ffffffff-ffff-4fff-bfff-ffffffffffff", u.String())
+	}
+
+	u, err = g.NewV7()
+	if assert.NoError(t, err) {
+		// Timestamp from RFC 9562 Appendix A.6 test vector.
+		assert.Equal(t, "urn:uuid:017f22e2-79b0-7fff-bfff-ffffffffffff", u.String())
+
+		id, err := urn.UUIDFromURN(u)
+		if assert.NoError(t, err) {
+			assert.Equal(t, 7, id.Version())
+			assert.Equal(t, urn.UUIDVariantRFC, id.Variant())
+		}
+	}
+
+	// Random source is exhausted.
+	_, err = g.NewV4()
+	assert.Error(t, err)
+
+	for i, now := range []time.Time{
+		time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC),
+		time.UnixMilli(1 << 48),
+		time.Date(11000, 1, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		msg := fmt.Sprintf("case %d: %v", i+1, now)
+
+		g := &urn.UUIDGenerator{
+			Rand: bytes.NewReader(make([]byte, 10)),
+			Now:  func() time.Time { return now },
+		}
+
+		var uerr *urn.Error
+
+		_, err = g.NewV7()
+		if assert.ErrorAs(t, err, &uerr, msg) {
+			assert.ErrorIs(t, err, urn.ErrTimeOutOfRange, msg)
+			assert.Equal(t, "uuid", uerr.Op, msg)
+		}
+	}
+
+	// Times beyond the range of UnixNano are still valid.
+	g = &urn.UUIDGenerator{
+		Rand: bytes.NewReader(make([]byte, 10)),
+		Now:  func() time.Time { return time.Date(2300, 1, 1, 0, 0, 0, 0, time.UTC) },
+	}
+
+	u, err = g.NewV7()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "urn:uuid:0978a65f-7800-7000-8000-000000000000", u.String())
+	}
+
+	// The last millisecond before the 48-bit timestamp overflows.
+	g = &urn.UUIDGenerator{
+		Rand: bytes.NewReader(make([]byte, 10)),
+		Now:  func() time.Time { return time.UnixMilli(1<<48 - 1) },
+	}
+
+	u, err = g.NewV7()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "urn:uuid:ffffffff-ffff-7000-8000-000000000000", u.String())
+	}
+
+	var zero urn.UUIDGenerator
+
+	a, err := zero.NewV4()
+	if assert.NoError(t, err) {
+		b, _ := zero.NewV4()
+		assert.NotEqual(t, a.String(), b.String())
+	}
+}
+
+type uuidTestCase struct {
+	Input   string
+	Output  string
+	Version int
+	Variant urn.UUIDVariant
+	Err     bool
+}
+
+var uuidCases = []*uuidTestCase{
+	{
+		Input:   "f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+		Output:  "f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+		Version: 1,
+		Variant: urn.UUIDVariantRFC,
+	},
+	{
+		Input:   "F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6",
+		Output:  "f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+		Version: 1,
+		Variant: urn.UUIDVariantRFC,
+	},
+	{
+		Input:   "919108f7-52d1-4320-9bac-f847db4148a8",
+		Output:  "919108f7-52d1-4320-9bac-f847db4148a8",
+		Version: 4,
+		Variant: urn.UUIDVariantRFC,
+	},
+	{
+		Input:   "00000000-0000-0000-0000-000000000000",
+		Output:  "00000000-0000-0000-0000-000000000000",
+		Version: 0,
+		Variant: urn.UUIDVariantNCS,
+	},
+	{
+		Input:   "ffffffff-ffff-ffff-ffff-ffffffffffff",
+		Output:  "ffffffff-ffff-ffff-ffff-ffffffffffff",
+		Version: 15,
+		Variant: urn.UUIDVariantFuture,
+	},
+	{
+		Input:   "00000000-0000-0000-c000-000000000000",
+		Output:  "00000000-0000-0000-c000-000000000000",
+		Version: 0,
+		Variant: urn.UUIDVariantMicrosoft,
+	},
+	{Input: "", Err: true},
+	{Input: "not-a-uuid", Err: true},
+	{Input: "f81d4fae7dec11d0a76500a0c91e6bf6", Err: true},
+	{Input: "f81d4fae-7dec-11d0-a765-00a0c91e6bf", Err: true},
+	{Input: "f81d4fae-7dec-11d0-a765-00a0c91e6bf6a", Err: true},
+	{Input: "f81d4fae:7dec-11d0-a765-00a0c91e6bf6", Err: true},
+	{Input: "g81d4fae-7dec-11d0-a765-00a0c91e6bf6", Err: true},
+	{Input: "f81d4fae-7dec-11d0-a765-00a0c91e6b%46", Err: true},
+}